@@ -10,12 +10,15 @@ func main() {
 	var weather wwo.WWO
 
 	if len(os.Args) < 3 {
-		fmt.Fprintf(os.Stderr, "Usage: %s (apikey) (location)\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s (apikey) (location) [imperial]\n", os.Args[0])
 		os.Exit(1)
 	}
 
 	weather.Key = os.Args[1]
 	location := os.Args[2]
+	if len(os.Args) > 3 && os.Args[3] == "imperial" {
+		weather.Unit = wwo.Imperial
+	}
 
 	options := map[string]string{
 		"fx": "no",
@@ -33,34 +36,42 @@ func main() {
 	if cc.Time != 0 {
 		fmt.Print("at ", cc.Time, "\n")
 	}
-	if cc.Temp != 0 {
-		fmt.Print("Temperature\t", cc.Temp, "°C\n")
+	if t, u := cc.TempAs(weather.Unit); cc.Has("Temp") {
+		fmt.Print("Temperature\t", t, u, "\n")
 	}
-	if cc.FeelsLike != 0 {
-		fmt.Print("Feels Like\t", cc.FeelsLike, "°C\n")
+	if cc.Has("FeelsLike") {
+		if weather.Unit == wwo.Imperial {
+			fmt.Print("Feels Like\t", cc.FeelsLikeF, "°F\n")
+		} else {
+			fmt.Print("Feels Like\t", cc.FeelsLike, "°C\n")
+		}
 	}
 	if cc.Humidity != 0 {
 		fmt.Print("Humidity\t", cc.Humidity, "%\n")
 	}
-	if cc.DewPoint != 0 {
-		fmt.Print("Dew Point\t", cc.DewPoint, "°C\n")
+	if cc.Has("DewPoint") {
+		if weather.Unit == wwo.Imperial {
+			fmt.Print("Dew Point\t", cc.DewPointF, "°F\n")
+		} else {
+			fmt.Print("Dew Point\t", cc.DewPoint, "°C\n")
+		}
 	}
-	if cc.Pressure != 0 {
-		fmt.Print("Pressure\t", cc.Pressure, "mbar\n")
+	if p, u := cc.PressureAs(weather.Unit); p != 0 {
+		fmt.Print("Pressure\t", p, u, "\n")
 	}
-	if cc.Visibility != 0 {
-		fmt.Print("Visibility\t", cc.Visibility, "km\n")
+	if v, u := cc.VisibilityAs(weather.Unit); v != 0 {
+		fmt.Print("Visibility\t", v, u, "\n")
 	}
 	if cc.CloudCover != 0 {
 		fmt.Print("Cloud cover\t", cc.CloudCover, "%\n")
 	}
-	if cc.Precip != 0 {
-		fmt.Print("Precipitation\t", cc.Precip, "mm\n")
+	if p, u := cc.PrecipAs(weather.Unit); p != 0 {
+		fmt.Print("Precipitation\t", p, u, "\n")
 	}
-	if cc.WindSpeed != 0 {
-		fmt.Print("Wind Speed\t", cc.WindSpeed, "km/h\n")
+	if s, u := cc.WindSpeedAs(weather.Unit); s != 0 {
+		fmt.Print("Wind Speed\t", s, u, "\n")
 	}
-	if cc.WindDir != 0 {
+	if cc.Has("WindDir") {
 		fmt.Print("Wind Direction\t", cc.WindDir, "°E of N (", cc.WindDirCompass, ")\n")
 	}
 }
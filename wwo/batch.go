@@ -0,0 +1,171 @@
+package wwo
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Fetch local forecasts for each of locations concurrently, as with GetLocal.
+//
+// The results and errors are in the same order as locations,
+// and a failure for one location does not affect the others.
+func (w *WWO) GetLocalBatch(locations []string, opt map[string]string) ([]*Local, []error) {
+	var results = make([]*Local, len(locations))
+	var errs = make([]error, len(locations))
+
+	w.batch(len(locations), func(i int) {
+		results[i], errs[i] = w.GetLocal(locations[i], opt)
+	})
+
+	return results, errs
+}
+
+// Look up locations for each of queries concurrently, as with GetSearch,
+// merging the areas found into one list without duplicates,
+// which are areas with the same weather URL, or coordinates if they have none.
+//
+// The areas are in the order of queries and then of their results.
+// Areas found are returned along with the first error, in the order of queries, if any failed,
+// and queries not yet made when ctx is done fail with its error.
+func (w *WWO) SearchBatch(ctx context.Context, queries []string, opt map[string]string) ([]Area, error) {
+	var results = make([]*Search, len(queries))
+	var errs = make([]error, len(queries))
+
+	w.batch(len(queries), func(i int) {
+		if errs[i] = ctx.Err(); errs[i] == nil {
+			results[i], errs[i] = w.GetSearchContext(ctx, queries[i], opt)
+		}
+	})
+
+	var areas []Area
+	var seen = make(map[string]bool)
+	var err error
+
+	for i := range queries {
+		if errs[i] != nil && err == nil {
+			err = errs[i]
+		}
+		if results[i] == nil {
+			continue
+		}
+		for _, a := range results[i].Area {
+			key := a.WeatherURL
+			if key == "" {
+				key = a.Query()
+			}
+			if !seen[key] {
+				seen[key] = true
+				areas = append(areas, a)
+			}
+		}
+	}
+
+	return areas, err
+}
+
+// Call f for each index below n, from at most Concurrency goroutines at once.
+func (w *WWO) batch(n int, f func(i int)) {
+	var workers = w.Concurrency
+
+	if workers <= 0 {
+		workers = 4
+	}
+	if workers > n {
+		workers = n
+	}
+
+	var indices = make(chan int)
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				f(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+
+	wg.Wait()
+}
+
+// Fetch local forecasts for several locations in a single request, as with GetLocal.
+//
+// The results and errors are in the same order as locations,
+// an error reported by the API for one location not affecting the others.
+// If the request itself fails every location has its error.
+func (w *WWO) GetLocalMulti(locations []string, opt map[string]string) ([]*Local, []error) {
+	var results = make([]*Local, len(locations))
+	var errs = make([]error, len(locations))
+
+	fail := func(err error) ([]*Local, []error) {
+		for i := range errs {
+			if errs[i] == nil && results[i] == nil {
+				errs[i] = err
+			}
+		}
+		return results, errs
+	}
+
+	query := locationQuery(strings.Join(locations, ";"), opt)
+	text, hit, err := w.fetch("weather", query)
+	if err != nil {
+		return fail(err)
+	}
+
+	var body = text
+	if w.Format == FormatJSON {
+		if body, err = jsonToXML(text); err != nil {
+			return fail(fmt.Errorf("wwo: decoding weather response %q: %w", snippet(text), err))
+		}
+	}
+
+	// Each location has its own data element, either within a root element or at the top level.
+	var d = xml.NewDecoder(bytes.NewReader(body))
+	var i int
+
+	for i < len(locations) {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail(fmt.Errorf("wwo: decoding weather response %q: %w", snippet(text), err))
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "data" {
+			continue
+		}
+
+		var o *Local = new(Local)
+		if w.KeepRaw {
+			o.Raw = append([]byte(nil), text...) // Each its own, as for separate requests
+		}
+		o.FromCache, o.CachedAt = hit.ok, hit.at
+		o.Options = query
+		if err := d.DecodeElement(o, &start); err != nil {
+			return fail(fmt.Errorf("wwo: decoding weather response %q: %w", snippet(text), err))
+		}
+
+		results[i] = o
+		if !w.RawErrors {
+			errs[i] = o.err()
+		}
+		i++
+	}
+
+	return fail(errors.New("wwo: no result for location"))
+}
@@ -0,0 +1,178 @@
+package wwo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// A handler answering each request with the body for its q parameter, or a 404 if there is none.
+func byQuery(bodies map[string]string) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[r.URL.Query().Get("q")]
+		if !ok {
+			http.NotFound(rw, r)
+			return
+		}
+		fmt.Fprint(rw, body)
+	}
+}
+
+// A local forecast whose current temperature is temp.
+func tempXML(temp int) string {
+	return fmt.Sprintf("<data><current_condition><temp_C>%d</temp_C></current_condition></data>", temp)
+}
+
+func TestGetLocalBatch(t *testing.T) {
+	var w = testWWO(t, byQuery(map[string]string{
+		"London":  tempXML(12),
+		"Paris":   tempXML(17),
+		"Nowhere": notFoundXML,
+		"Madrid":  tempXML(25),
+	}))
+	w.Concurrency = 2
+
+	locations := []string{"London", "Paris", "Nowhere", "Atlantis", "Madrid"}
+	results, errs := w.GetLocalBatch(locations, nil)
+
+	if len(results) != len(locations) || len(errs) != len(locations) {
+		t.Fatalf("%d results and %d errors for %d locations", len(results), len(errs), len(locations))
+	}
+
+	for i, temp := range map[int]int{0: 12, 1: 17, 4: 25} {
+		if errs[i] != nil {
+			t.Errorf("%s: %v", locations[i], errs[i])
+		} else if results[i].Current.Temp != temp {
+			t.Errorf("%s: Current.Temp = %d, want %d", locations[i], results[i].Current.Temp, temp)
+		}
+	}
+
+	if _, ok := errs[2].(*APIError); !ok {
+		t.Errorf("Nowhere: error %v, want an APIError", errs[2])
+	}
+	if he, ok := errs[3].(*HTTPError); !ok || he.StatusCode != http.StatusNotFound {
+		t.Errorf("Atlantis: error %v, want a 404 HTTPError", errs[3])
+	}
+}
+
+func TestGetLocalMulti(t *testing.T) {
+	var h = &recorder{body: `<root>` + tempXML(12) + notFoundXML + `</root>`}
+	var w = testWWO(t, h)
+
+	results, errs := w.GetLocalMulti([]string{"London", "Nowhere"}, nil)
+
+	if q := h.last().Query().Get("q"); q != "London;Nowhere" {
+		t.Errorf("q = %q, want London;Nowhere", q)
+	}
+	if errs[0] != nil || results[0] == nil || results[0].Current.Temp != 12 {
+		t.Errorf("London: %v, %v", results[0], errs[0])
+	}
+	if _, ok := errs[1].(*APIError); !ok || results[1] == nil {
+		t.Errorf("Nowhere: error %v, want an APIError with the result", errs[1])
+	}
+
+	// A response with fewer results than locations fails for those left.
+	results, errs = w.GetLocalMulti([]string{"London", "Nowhere", "Paris"}, nil)
+	if errs[0] != nil || results[2] != nil || errs[2] == nil {
+		t.Errorf("with a result missing: %v, %v", results, errs)
+	}
+
+	// Each result keeps its own copy of the response.
+	w.KeepRaw = true
+	results, _ = w.GetLocalMulti([]string{"London", "Nowhere"}, nil)
+	if len(results[0].Raw) == 0 || string(results[0].Raw) != string(results[1].Raw) {
+		t.Fatalf("Raw = %q, %q, want the response for both", results[0].Raw, results[1].Raw)
+	}
+	results[0].Raw[0] = 'x'
+	if results[1].Raw[0] == 'x' {
+		t.Errorf("results share Raw")
+	}
+}
+
+// A search result for the area named name at lat, lon.
+func resultXML(name string, lat, lon float64) string {
+	return fmt.Sprintf("<result><areaName>%s</areaName><latitude>%.3f</latitude><longitude>%.3f</longitude>"+
+		"<weatherUrl>https://www.worldweatheronline.com/%s-weather.aspx</weatherUrl></result>", name, lat, lon, strings.ToLower(name))
+}
+
+func TestSearchBatch(t *testing.T) {
+	var london, londonderry = resultXML("London", 51.517, -0.106), resultXML("Londonderry", 55.0, -7.317)
+	var w = testWWO(t, byQuery(map[string]string{
+		"lon":   "<data>" + london + londonderry + "</data>",
+		"londo": "<data>" + london + "</data>",
+	}))
+	w.Concurrency = 2
+
+	areas, err := w.SearchBatch(context.Background(), []string{"lon", "londo"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, a := range areas {
+		names = append(names, a.Name)
+	}
+	if fmt.Sprint(names) != "[London Londonderry]" {
+		t.Errorf("areas %v, want [London Londonderry]", names)
+	}
+}
+
+// One WWO, with a cache and limiter, shared by many goroutines, as run by go test -race.
+func TestConcurrentUse(t *testing.T) {
+	var cities = []string{"London", "Paris", "Madrid", "Rome", "Berlin", "Vienna", "Oslo", "Lisbon"}
+
+	var w = testWWO(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		switch path.Base(r.URL.Path) {
+		case "weather.ashx":
+			fmt.Fprint(rw, tempXML(len(q)))
+		case "search.ashx":
+			fmt.Fprint(rw, "<data>"+resultXML(q, float64(len(q)), 0)+"</data>")
+		default:
+			http.NotFound(rw, r)
+		}
+	}))
+	w.Cache = NewMemoryCache()
+	w.Limiter = NewLimiter(1000)
+	w.Concurrency = 3
+
+	var wg sync.WaitGroup
+	for g := 0; g < 24; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+
+			switch g % 3 {
+			case 0:
+				city := cities[g%len(cities)]
+				l, err := w.GetLocal(city, map[string]string{"tp": "3"})
+				if err != nil {
+					t.Errorf("GetLocal(%s): %v", city, err)
+				} else if l.Current.Temp != len(city) {
+					t.Errorf("GetLocal(%s): Current.Temp = %d, want %d", city, l.Current.Temp, len(city))
+				}
+			case 1:
+				results, errs := w.GetLocalBatch(cities, nil)
+				for i, city := range cities {
+					if errs[i] != nil {
+						t.Errorf("GetLocalBatch: %s: %v", city, errs[i])
+					} else if results[i].Current.Temp != len(city) {
+						t.Errorf("GetLocalBatch: %s: Current.Temp = %d, want %d", city, results[i].Current.Temp, len(city))
+					}
+				}
+			case 2:
+				areas, err := w.SearchBatch(context.Background(), cities, nil)
+				if err != nil {
+					t.Errorf("SearchBatch: %v", err)
+				} else if len(areas) != len(cities) {
+					t.Errorf("SearchBatch: %d areas, want %d", len(areas), len(cities))
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,89 @@
+package wwo
+
+import (
+	"sync"
+	"time"
+)
+
+// Stores responses so repeated requests can be answered without the API.
+//
+// Keys are request URLs without the API key,
+// and values are only set for successful responses.
+// A Cache used by a WWO shared between goroutines must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration) // Keep value for ttl, or indefinitely if zero
+}
+
+// Optionally implemented by a Cache to give the time each value was set,
+// which results from it report as CachedAt.
+type TimedCache interface {
+	Cache
+	GetTimed(key string) ([]byte, time.Time, bool)
+}
+
+// Whether a response came from a Cache, and when it was set if known.
+type cacheHit struct {
+	ok bool
+	at time.Time
+}
+
+// Get the value for key from c, with the time it was set if c is a TimedCache.
+func cacheGet(c Cache, key string) ([]byte, cacheHit) {
+	if tc, ok := c.(TimedCache); ok {
+		value, at, ok := tc.GetTimed(key)
+		return value, cacheHit{ok, at}
+	}
+
+	value, ok := c.Get(key)
+	return value, cacheHit{ok: ok}
+}
+
+// A Cache held in memory, which may be shared between goroutines.
+// Values are copied in and out, so neither the caller setting a value nor one getting it can change the entry.
+// It is also a TimedCache.
+func NewMemoryCache() Cache {
+	return &memoryCache{entries: make(map[string]cacheEntry)}
+}
+
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value   []byte
+	set     time.Time
+	expires time.Time // Zero if never
+}
+
+func (c *memoryCache) Get(key string) ([]byte, bool) {
+	value, _, ok := c.GetTimed(key)
+	return value, ok
+}
+
+func (c *memoryCache) GetTimed(key string) ([]byte, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		delete(c.entries, key)
+		return nil, time.Time{}, false
+	}
+	return append([]byte(nil), e.value...), e.set, true
+}
+
+func (c *memoryCache) Set(key string, value []byte, ttl time.Duration) {
+	var e = cacheEntry{value: append([]byte(nil), value...), set: time.Now()}
+	if ttl > 0 {
+		e.expires = e.set.Add(ttl)
+	}
+
+	c.mu.Lock()
+	c.entries[key] = e
+	c.mu.Unlock()
+}
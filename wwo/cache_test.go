@@ -0,0 +1,84 @@
+package wwo
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+const notFoundXML = `<data><error><msg>Unable to find any matching weather location to the query submitted!</msg></error></data>`
+
+func TestCache(t *testing.T) {
+	var rt = &cannedTransport{body: currentXML}
+	var w = &WWO{Key: "k", HTTPClient: &http.Client{Transport: rt}, Cache: NewMemoryCache()}
+
+	for i := 0; i < 2; i++ {
+		l, err := w.GetLocal("London", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l.Current.Temp != 12 {
+			t.Errorf("Current.Temp = %d, want 12", l.Current.Temp)
+		}
+	}
+	if rt.count() != 1 {
+		t.Errorf("%d requests made for the same query, want 1", rt.count())
+	}
+
+	// Other options are another query.
+	if _, err := w.GetLocal("London", map[string]string{"tp": "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if rt.count() != 2 {
+		t.Errorf("%d requests made, want 2", rt.count())
+	}
+
+	// Errors reported by the API are not cached.
+	rt.body = notFoundXML
+	for i := 0; i < 2; i++ {
+		if _, err := w.GetLocal("Nowhere", nil); err == nil {
+			t.Error("no error for an unknown location")
+		}
+	}
+	if rt.count() != 4 {
+		t.Errorf("%d requests made, want the error response requested each time", rt.count()-2)
+	}
+
+	// Values are copied, so changing one set or got does not change the entry.
+	var c = NewMemoryCache()
+	var v = []byte("abc")
+	c.Set("k", v, 0)
+	v[0] = 'x'
+	got, _ := c.Get("k")
+	got[1] = 'y'
+	timed, _, _ := c.(TimedCache).GetTimed("k")
+	timed[2] = 'z'
+	if got, _ := c.Get("k"); string(got) != "abc" {
+		t.Errorf("cached value changed to %q, want %q", got, "abc")
+	}
+}
+
+func TestFromCache(t *testing.T) {
+	var rt = &cannedTransport{body: currentXML}
+	var w = &WWO{Key: "k", HTTPClient: &http.Client{Transport: rt}, Cache: NewMemoryCache()}
+
+	before := time.Now()
+	l, err := w.GetLocal("London", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.FromCache || !l.CachedAt.IsZero() {
+		t.Errorf("first response FromCache %v, CachedAt %v, want a fresh response", l.FromCache, l.CachedAt)
+	}
+
+	l, err = w.GetLocal("London", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !l.FromCache {
+		t.Error("second response not FromCache")
+	}
+	if l.CachedAt.Before(before) || l.CachedAt.After(time.Now()) {
+		t.Errorf("CachedAt %v, want the time of the first request", l.CachedAt)
+	}
+}
@@ -0,0 +1,124 @@
+package wwo
+
+// The WHO category of a UV index: Low, Moderate, High, Very High, or Extreme.
+func UVCategory(index uint) string {
+	switch {
+	case index <= 2:
+		return "Low"
+	case index <= 5:
+		return "Moderate"
+	case index <= 7:
+		return "High"
+	case index <= 10:
+		return "Very High"
+	}
+	return "Extreme"
+}
+
+// The WHO colour for the category of a UV index, as a hex RGB value, e.g. #289500 for Low.
+func UVColor(index uint) string {
+	switch {
+	case index <= 2:
+		return "#289500"
+	case index <= 5:
+		return "#F7E400"
+	case index <= 7:
+		return "#F85900"
+	case index <= 10:
+		return "#D8001D"
+	}
+	return "#6B49C8"
+}
+
+// The category of the visibility, by the Met Office bands merged into four:
+// Poor below 4 km, Moderate below 10 km, Good below 40 km, and Excellent otherwise.
+func (c *Condition) VisibilityCategory() string {
+	switch {
+	case c.Visibility < 4:
+		return "Poor"
+	case c.Visibility < 10:
+		return "Moderate"
+	case c.Visibility < 40:
+		return "Good"
+	}
+	return "Excellent"
+}
+
+// The intensity of the precipitation, by the Met Office rates per hour:
+// None, Light below 2 mm, Moderate below 10 mm, Heavy below 50 mm, and Violent otherwise.
+//
+// tp is the number of hours the conditions cover, as requested with the tp option and given by Local.TP, 3 if zero,
+// so conditions at different intervals are comparable.
+func (c *Condition) PrecipIntensity(tp int) string {
+	if tp <= 0 {
+		tp = 3
+	}
+
+	rate := c.Precip / float64(tp)
+	switch {
+	case rate <= 0:
+		return "None"
+	case rate < 2:
+		return "Light"
+	case rate < 10:
+		return "Moderate"
+	case rate < 50:
+		return "Heavy"
+	}
+	return "Violent"
+}
+
+// The degree of the Douglas sea scale for the significant wave height, and its label, e.g. 5 and "Rough".
+func (c *MarineCondition) SeaState() (degree int, label string) {
+	var scale = []struct {
+		min   float64 // m  Lowest significant wave height of the degree
+		label string
+	}{
+		{0, "Calm (glassy)"},
+		{0.01, "Calm (rippled)"},
+		{0.1, "Smooth"},
+		{0.5, "Slight"},
+		{1.25, "Moderate"},
+		{2.5, "Rough"},
+		{4, "Very rough"},
+		{6, "High"},
+		{9, "Very high"},
+		{14, "Phenomenal"},
+	}
+
+	for d := len(scale) - 1; d > 0; d-- {
+		if c.SigHeight >= scale[d].min {
+			return d, scale[d].label
+		}
+	}
+	return 0, scale[0].label
+}
+
+// The Beaufort force of the wind speed, and its label, e.g. 4 and "Moderate breeze".
+func (c *Condition) WindForce() (force int, label string) {
+	var scale = []struct {
+		min   uint // knots  Lowest wind speed of the force
+		label string
+	}{
+		{0, "Calm"},
+		{1, "Light air"},
+		{4, "Light breeze"},
+		{7, "Gentle breeze"},
+		{11, "Moderate breeze"},
+		{17, "Fresh breeze"},
+		{22, "Strong breeze"},
+		{28, "Near gale"},
+		{34, "Gale"},
+		{41, "Strong gale"},
+		{48, "Storm"},
+		{56, "Violent storm"},
+		{64, "Hurricane force"},
+	}
+
+	for f := len(scale) - 1; f > 0; f-- {
+		if c.WindSpeedKnots >= scale[f].min {
+			return f, scale[f].label
+		}
+	}
+	return 0, scale[0].label
+}
@@ -0,0 +1,119 @@
+package wwo
+
+import (
+	"testing"
+)
+
+func TestUVCategory(t *testing.T) {
+	for _, tt := range []struct {
+		index    uint
+		category string
+		color    string
+	}{
+		{0, "Low", "#289500"},
+		{2, "Low", "#289500"},
+		{3, "Moderate", "#F7E400"},
+		{7, "High", "#F85900"},
+		{8, "Very High", "#D8001D"},
+		{10, "Very High", "#D8001D"},
+		{11, "Extreme", "#6B49C8"},
+	} {
+		if got := UVCategory(tt.index); got != tt.category {
+			t.Errorf("UVCategory(%d) = %q, want %q", tt.index, got, tt.category)
+		}
+		if got := UVColor(tt.index); got != tt.color {
+			t.Errorf("UVColor(%d) = %q, want %q", tt.index, got, tt.color)
+		}
+	}
+}
+
+func TestVisibilityCategory(t *testing.T) {
+	for _, tt := range []struct {
+		km       uint
+		category string
+	}{
+		{1, "Poor"},
+		{3, "Poor"},
+		{4, "Moderate"},
+		{5, "Moderate"},
+		{10, "Good"},
+		{39, "Good"},
+		{40, "Excellent"},
+	} {
+		var c = Condition{Visibility: tt.km, VisibilityMiles: tt.km * 5 / 8}
+		if got := c.VisibilityCategory(); got != tt.category {
+			t.Errorf("VisibilityCategory() at %d km = %q, want %q", tt.km, got, tt.category)
+		}
+		if m, err := c.VisibilityIn("m"); err != nil || m != float64(tt.km*1000) {
+			t.Errorf("VisibilityIn(\"m\") at %d km = %v, %v", tt.km, m, err)
+		}
+		if mi, err := c.VisibilityIn("mi"); err != nil || mi != float64(c.VisibilityMiles) {
+			t.Errorf("VisibilityIn(\"mi\") at %d km = %v, %v", tt.km, mi, err)
+		}
+	}
+}
+
+func TestPrecipIntensity(t *testing.T) {
+	for _, tt := range []struct {
+		mm   float64
+		tp   int
+		want string
+	}{
+		{0, 3, "None"},
+		{0.5, 3, "Light"},
+		{0.5, 0, "Light"},
+		{10, 1, "Heavy"},
+		{10, 3, "Moderate"},
+		{10, 24, "Light"},
+		{50, 1, "Violent"},
+	} {
+		var c = Condition{Precip: tt.mm}
+		if got := c.PrecipIntensity(tt.tp); got != tt.want {
+			t.Errorf("PrecipIntensity(%d) of %v mm = %q, want %q", tt.tp, tt.mm, got, tt.want)
+		}
+	}
+}
+
+func TestSeaState(t *testing.T) {
+	for _, tt := range []struct {
+		m      float64
+		degree int
+		label  string
+	}{
+		{0, 0, "Calm (glassy)"},
+		{0.3, 2, "Smooth"},
+		{1.25, 4, "Moderate"},
+		{2.49, 4, "Moderate"},
+		{2.5, 5, "Rough"},
+		{15, 9, "Phenomenal"},
+	} {
+		var c = MarineCondition{SigHeight: tt.m}
+		if degree, label := c.SeaState(); degree != tt.degree || label != tt.label {
+			t.Errorf("SeaState() at %v m = %d, %q, want %d, %q", tt.m, degree, label, tt.degree, tt.label)
+		}
+	}
+}
+
+func TestWindForce(t *testing.T) {
+	for _, tt := range []struct {
+		knots uint
+		force int
+		label string
+	}{
+		{0, 0, "Calm"},
+		{1, 1, "Light air"},
+		{3, 1, "Light air"},
+		{4, 2, "Light breeze"},
+		{10, 3, "Gentle breeze"},
+		{11, 4, "Moderate breeze"},
+		{33, 7, "Near gale"},
+		{34, 8, "Gale"},
+		{63, 11, "Violent storm"},
+		{64, 12, "Hurricane force"},
+	} {
+		var c = Condition{WindSpeedKnots: tt.knots}
+		if force, label := c.WindForce(); force != tt.force || label != tt.label {
+			t.Errorf("WindForce() at %d knots = %d, %q, want %d, %q", tt.knots, force, label, tt.force, tt.label)
+		}
+	}
+}
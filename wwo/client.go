@@ -0,0 +1,66 @@
+package wwo
+
+import (
+	"net/http"
+	"os"
+	"time"
+)
+
+// Sets a field of a WWO being made by NewWWO.
+type Option func(w *WWO)
+
+// A WWO for the API key, with the options applied in order.
+// Fields not set by an option keep their zero values, and so their defaults.
+func NewWWO(key string, opts ...Option) *WWO {
+	var w = &WWO{Key: key}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Environment variable NewWWOFromEnv reads the API key from.
+const KeyEnv = "WWO_API_KEY"
+
+// A WWO for the API key in the WWO_API_KEY environment variable, with the options applied as by NewWWO.
+// ErrNoKey is returned if the variable is unset or empty.
+func NewWWOFromEnv(opts ...Option) (*WWO, error) {
+	key := os.Getenv(KeyEnv)
+	if key == "" {
+		return nil, ErrNoKey
+	}
+
+	return NewWWO(key, opts...), nil
+}
+
+// Use client for requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(w *WWO) { w.HTTPClient = client }
+}
+
+// Limit the time taken by each request, or retry.
+func WithTimeout(d time.Duration) Option {
+	return func(w *WWO) { w.Timeout = d }
+}
+
+// Make requests for the plan t.
+func WithTier(t Tier) Option {
+	return func(w *WWO) { w.Tier = t }
+}
+
+// Prefer the system of units u for presenting conditions.
+func WithUnit(u Unit) Option {
+	return func(w *WWO) { w.Unit = u }
+}
+
+// Use http rather than https, which sends the API key in plain text.
+func WithInsecure() Option {
+	return func(w *WWO) { w.Insecure = true }
+}
+
+// Log each response to l.
+func WithLogger(l Logger) Option {
+	return func(w *WWO) { w.Logger = l }
+}
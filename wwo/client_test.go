@@ -0,0 +1,53 @@
+package wwo
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewWWO(t *testing.T) {
+	var client = new(http.Client)
+	var logger = log.New(os.Stderr, "", 0)
+
+	w := NewWWO("k", WithHTTPClient(client), WithTimeout(5*time.Second), WithTier(Free), WithUnit(Imperial), WithInsecure(), WithLogger(logger))
+
+	if w.Key != "k" || w.HTTPClient != client || w.Timeout != 5*time.Second || w.Tier != Free || w.Unit != Imperial || !w.Insecure || w.Logger != logger {
+		t.Errorf("NewWWO() = %+v", w)
+	}
+
+	// Options are applied in order.
+	if w := NewWWO("k", WithTimeout(time.Second), WithTimeout(2*time.Second)); w.Timeout != 2*time.Second {
+		t.Errorf("Timeout = %v, want the last one given", w.Timeout)
+	}
+
+	// Fields without options keep their defaults.
+	if w := NewWWO("k"); !reflect.DeepEqual(w, &WWO{Key: "k"}) {
+		t.Errorf("NewWWO() without options = %+v", w)
+	}
+}
+
+func TestNewWWOFromEnv(t *testing.T) {
+	t.Setenv(KeyEnv, "envkey")
+
+	w, err := NewWWOFromEnv(WithTier(Free))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Key != "envkey" || w.Tier != Free {
+		t.Errorf("NewWWOFromEnv() = %+v, want the key from %s", w, KeyEnv)
+	}
+
+	t.Setenv(KeyEnv, "")
+	if w, err := NewWWOFromEnv(); w != nil || err != ErrNoKey {
+		t.Errorf("NewWWOFromEnv() with %s empty = %v, %v, want ErrNoKey", KeyEnv, w, err)
+	}
+
+	os.Unsetenv(KeyEnv) // Restored by Setenv after the test
+	if w, err := NewWWOFromEnv(); w != nil || err != ErrNoKey {
+		t.Errorf("NewWWOFromEnv() with %s unset = %v, %v, want ErrNoKey", KeyEnv, w, err)
+	}
+}
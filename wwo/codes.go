@@ -0,0 +1,111 @@
+package wwo
+
+import (
+	"strconv"
+)
+
+// A weather condition code, as given in WeatherCode,
+// from the table at <https://developer.worldweatheronline.com/api/docs/weather-icons.aspx>.
+type WeatherCondition uint
+
+type weatherConditionInfo struct {
+	description string
+	category    string
+}
+
+var weatherConditions = map[WeatherCondition]weatherConditionInfo{
+	113: {"Clear/Sunny", "Clear"},
+	116: {"Partly Cloudy", "Cloudy"},
+	119: {"Cloudy", "Cloudy"},
+	122: {"Overcast", "Cloudy"},
+	143: {"Mist", "Fog"},
+	176: {"Patchy rain nearby", "Rain"},
+	179: {"Patchy snow nearby", "Snow"},
+	182: {"Patchy sleet nearby", "Sleet"},
+	185: {"Patchy freezing drizzle nearby", "Drizzle"},
+	200: {"Thundery outbreaks in nearby", "Thunder"},
+	227: {"Blowing snow", "Snow"},
+	230: {"Blizzard", "Snow"},
+	248: {"Fog", "Fog"},
+	260: {"Freezing fog", "Fog"},
+	263: {"Patchy light drizzle", "Drizzle"},
+	266: {"Light drizzle", "Drizzle"},
+	281: {"Freezing drizzle", "Drizzle"},
+	284: {"Heavy freezing drizzle", "Drizzle"},
+	293: {"Patchy light rain", "Rain"},
+	296: {"Light rain", "Rain"},
+	299: {"Moderate rain at times", "Rain"},
+	302: {"Moderate rain", "Rain"},
+	305: {"Heavy rain at times", "Rain"},
+	308: {"Heavy rain", "Rain"},
+	311: {"Light freezing rain", "Rain"},
+	314: {"Moderate or Heavy freezing rain", "Rain"},
+	317: {"Light sleet", "Sleet"},
+	320: {"Moderate or heavy sleet", "Sleet"},
+	323: {"Patchy light snow", "Snow"},
+	326: {"Light snow", "Snow"},
+	329: {"Patchy moderate snow", "Snow"},
+	332: {"Moderate snow", "Snow"},
+	335: {"Patchy heavy snow", "Snow"},
+	338: {"Heavy snow", "Snow"},
+	350: {"Ice pellets", "Ice"},
+	353: {"Light rain shower", "Rain"},
+	356: {"Moderate or heavy rain shower", "Rain"},
+	359: {"Torrential rain shower", "Rain"},
+	362: {"Light sleet showers", "Sleet"},
+	365: {"Moderate or heavy sleet showers", "Sleet"},
+	368: {"Light snow showers", "Snow"},
+	371: {"Moderate or heavy snow showers", "Snow"},
+	374: {"Light showers of ice pellets", "Ice"},
+	377: {"Moderate or heavy showers of ice pellets", "Ice"},
+	386: {"Patchy light rain in area with thunder", "Thunder"},
+	389: {"Moderate or heavy rain in area with thunder", "Thunder"},
+	392: {"Patchy light snow in area with thunder", "Thunder"},
+	395: {"Moderate or heavy snow in area with thunder", "Thunder"},
+}
+
+// The code of the conditions' weather.
+func (c *Condition) WeatherCondition() WeatherCondition {
+	return WeatherCondition(c.WeatherCode)
+}
+
+// The description of the code in the WorldWeatherOnline table, e.g. "Light drizzle",
+// or the code itself if it is not in the table.
+func (w WeatherCondition) String() string {
+	if i, ok := weatherConditions[w]; ok {
+		return i.description
+	}
+	return "WeatherCondition(" + strconv.FormatUint(uint64(w), 10) + ")"
+}
+
+// Whether the code is in the WorldWeatherOnline table.
+func (w WeatherCondition) Known() bool {
+	_, ok := weatherConditions[w]
+	return ok
+}
+
+// The broad category of the weather:
+// Clear, Cloudy, Fog, Drizzle, Rain, Sleet, Snow, Ice, or Thunder,
+// and empty for unknown codes.
+func (w WeatherCondition) Category() string {
+	return weatherConditions[w].category
+}
+
+// Whether the weather is clear or sunny.
+func (w WeatherCondition) IsClear() bool {
+	return w.Category() == "Clear"
+}
+
+// Whether the weather includes rain or drizzle, including with thunder.
+func (w WeatherCondition) IsRain() bool {
+	switch w.Category() {
+	case "Rain", "Drizzle":
+		return true
+	}
+	return w == 386 || w == 389
+}
+
+// Whether the weather includes snow, including with thunder.
+func (w WeatherCondition) IsSnow() bool {
+	return w.Category() == "Snow" || w == 392 || w == 395
+}
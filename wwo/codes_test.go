@@ -0,0 +1,37 @@
+package wwo
+
+import (
+	"testing"
+)
+
+func TestWeatherCondition(t *testing.T) {
+	for _, tt := range []struct {
+		code              WeatherCondition
+		category          string
+		clear, rain, snow bool
+	}{
+		{113, "Clear", true, false, false},
+		{266, "Drizzle", false, true, false},
+		{296, "Rain", false, true, false},
+		{389, "Thunder", false, true, false},
+		{395, "Thunder", false, false, true},
+		{338, "Snow", false, false, true},
+		{999, "", false, false, false},
+	} {
+		w := tt.code
+		if w.Category() != tt.category || w.IsClear() != tt.clear || w.IsRain() != tt.rain || w.IsSnow() != tt.snow {
+			t.Errorf("%d: Category() %q, IsClear() %v, IsRain() %v, IsSnow() %v, want %q, %v, %v, %v",
+				w, w.Category(), w.IsClear(), w.IsRain(), w.IsSnow(), tt.category, tt.clear, tt.rain, tt.snow)
+		}
+		if w.Known() != (tt.category != "") {
+			t.Errorf("%d: Known() = %v", w, w.Known())
+		}
+	}
+
+	if got := WeatherCondition(113).String(); got != "Clear/Sunny" {
+		t.Errorf("String() of 113 = %q, want Clear/Sunny", got)
+	}
+	if got := WeatherCondition(999).String(); got != "WeatherCondition(999)" {
+		t.Errorf("String() of 999 = %q, want WeatherCondition(999)", got)
+	}
+}
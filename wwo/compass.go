@@ -0,0 +1,33 @@
+package wwo
+
+import (
+	"math"
+	"strings"
+)
+
+// The points of the 16-point compass, clockwise from north.
+var compassPoints = [16]string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+// The direction in degrees east of north of a 16-point compass point, e.g. 22.5 for NNE.
+// ok is false if point is not one of the 16.
+func CompassToDegrees(point string) (deg float64, ok bool) {
+	for i, p := range compassPoints {
+		if strings.EqualFold(p, point) {
+			return float64(i) * 22.5, true
+		}
+	}
+	return 0, false
+}
+
+// The 16-point compass point nearest a direction in degrees east of north.
+// Directions halfway between two points give the clockwise one, e.g. NNE for 11.25.
+func DegreesToCompass(deg float64) string {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return compassPoints[int(math.Floor(deg/22.5+0.5))%16]
+}
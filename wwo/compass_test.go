@@ -0,0 +1,37 @@
+package wwo
+
+import (
+	"testing"
+)
+
+func TestCompass(t *testing.T) {
+	for i, point := range []string{
+		"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+		"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+	} {
+		deg := float64(i) * 22.5
+
+		if got, ok := CompassToDegrees(point); !ok || got != deg {
+			t.Errorf("CompassToDegrees(%q) = %v, %v, want %v", point, got, ok, deg)
+		}
+		if got := DegreesToCompass(deg); got != point {
+			t.Errorf("DegreesToCompass(%v) = %q, want %q", deg, got, point)
+		}
+	}
+
+	for deg, want := range map[float64]string{
+		348.75: "N",
+		11.25:  "NNE",
+		11.24:  "N",
+		360:    "N",
+		-22.5:  "NNW",
+	} {
+		if got := DegreesToCompass(deg); got != want {
+			t.Errorf("DegreesToCompass(%v) = %q, want %q", deg, got, want)
+		}
+	}
+
+	if _, ok := CompassToDegrees("NNN"); ok {
+		t.Error("CompassToDegrees accepted NNN")
+	}
+}
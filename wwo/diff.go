@@ -0,0 +1,78 @@
+package wwo
+
+import (
+	"math"
+	"reflect"
+	"time"
+)
+
+// The changes allowed in each kind of field before Diff reports it as changed.
+type Tolerance struct {
+	Temp    int     // °C     Temperatures
+	Percent uint    // %      Chances, humidity, and cloud cover
+	Wind    uint    // km/hr  Wind speed and gusts
+	Precip  float64 // mm     Precipitation
+}
+
+// Tolerances for changes that matter to most forecasts.
+var DefaultTolerance = Tolerance{Temp: 2, Percent: 10, Wind: 10, Precip: 1}
+
+// Whether the conditions' decoded fields are all the same as other's,
+// ignoring which elements the response gave and the absolute time set by LocalizeTimes.
+func (c ForecastCondition) Equal(other ForecastCondition) bool {
+	c.given, other.given = nil, nil
+	c.At, other.At = time.Time{}, time.Time{}
+	return reflect.DeepEqual(c, other)
+}
+
+// The names of the fields, e.g. "Temp" or "ChanceRain", that differ in other by more than tol,
+// or at all for the weather code.
+func (c ForecastCondition) Diff(other ForecastCondition, tol Tolerance) []string {
+	var changed []string
+
+	ints := []struct {
+		name string
+		a, b int
+		tol  int
+	}{
+		{"Temp", c.Temp, other.Temp, tol.Temp},
+		{"FeelsLike", c.FeelsLike, other.FeelsLike, tol.Temp},
+		{"WindSpeed", int(c.WindSpeed), int(other.WindSpeed), int(tol.Wind)},
+		{"WindGust", int(c.WindGust), int(other.WindGust), int(tol.Wind)},
+		{"Humidity", int(c.Humidity), int(other.Humidity), int(tol.Percent)},
+		{"CloudCover", int(c.CloudCover), int(other.CloudCover), int(tol.Percent)},
+		{"WeatherCode", int(c.WeatherCode), int(other.WeatherCode), 0},
+	}
+	for _, f := range ints {
+		if f.a-f.b > f.tol || f.b-f.a > f.tol {
+			changed = append(changed, f.name)
+		}
+	}
+
+	if math.Abs(c.Precip-other.Precip) > tol.Precip {
+		changed = append(changed, "Precip")
+	}
+
+	a, b := c.ForecastChances.chances(), other.ForecastChances.chances()
+	for i := range a {
+		if d := int(a[i].Pct) - int(b[i].Pct); d > int(tol.Percent) || -d > int(tol.Percent) {
+			changed = append(changed, chanceFields[i])
+		}
+	}
+
+	return changed
+}
+
+// The names of the fields of ForecastChances, in the order given by chances.
+var chanceFields = []string{
+	"ChanceFog",
+	"ChanceFrost",
+	"ChanceOvercast",
+	"ChanceRain",
+	"ChanceSnow",
+	"ChanceHighTemp",
+	"ChanceDry",
+	"ChanceSunshine",
+	"ChanceThunder",
+	"ChanceWindy",
+}
@@ -0,0 +1,35 @@
+package wwo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	var a ForecastCondition
+	a.Temp, a.ChanceRain, a.WeatherCode = 15, 20, 116
+
+	for _, tt := range []struct {
+		name   string
+		change func(c *ForecastCondition)
+		want   []string
+	}{
+		{"same", func(c *ForecastCondition) {}, nil},
+		{"same, with other elements given", func(c *ForecastCondition) { c.given = elementSet{"tempC": true} }, nil},
+		{"within tolerance", func(c *ForecastCondition) { c.Temp += 2; c.ChanceRain += 10 }, nil},
+		{"temp shift", func(c *ForecastCondition) { c.Temp += 3 }, []string{"Temp"}},
+		{"temp drop", func(c *ForecastCondition) { c.Temp -= 3 }, []string{"Temp"}},
+		{"rain", func(c *ForecastCondition) { c.ChanceRain = 80; c.WeatherCode = 296 }, []string{"WeatherCode", "ChanceRain"}},
+	} {
+		b := a
+		tt.change(&b)
+
+		if got := a.Diff(b, DefaultTolerance); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: Diff() = %v, want %v", tt.name, got, tt.want)
+		}
+		if eq := a.Equal(b); eq != strings.HasPrefix(tt.name, "same") {
+			t.Errorf("%s: Equal() = %v", tt.name, eq)
+		}
+	}
+}
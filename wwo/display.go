@@ -0,0 +1,64 @@
+package wwo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The area's name, region, and country, e.g. "London, City of London, Greater London, United Kingdom",
+// omitting those that are not given.
+func (a Area) String() string {
+	var parts []string
+
+	for _, p := range []string{a.Name, a.Region, a.Country} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// A summary of the conditions, e.g. "12°C, Partly cloudy, wind 15 km/h NW, humidity 80%".
+func (c Condition) String() string {
+	return c.StringAs(Metric)
+}
+
+// A summary of the conditions in the system of units u, e.g. "54°F, Partly cloudy, wind 9 mph NW, humidity 80%".
+func (c Condition) StringAs(u Unit) string {
+	temp, unit := c.TempAs(u)
+	return c.describe(u, temp, unit)
+}
+
+// A summary of the current conditions, e.g. "12°C, Partly cloudy, wind 15 km/h NW, humidity 80%".
+func (c CurrentCondition) String() string {
+	return c.StringAs(Metric)
+}
+
+// A summary of the current conditions in the system of units u, e.g. "54°F, Partly cloudy, wind 9 mph NW, humidity 80%".
+func (c CurrentCondition) StringAs(u Unit) string {
+	temp, unit := c.TempAs(u)
+	return c.describe(u, temp, unit)
+}
+
+// Describe the conditions in the system of units u with the temperature temp, in unit, which is always included,
+// omitting the other parts that are not given.
+func (c *Condition) describe(u Unit, temp int, unit string) string {
+	var parts = []string{strconv.Itoa(temp) + unit}
+
+	if c.WeatherDesc != "" {
+		parts = append(parts, c.WeatherDesc)
+	}
+	if speed, unit := c.WindSpeedAs(u); speed != 0 {
+		wind := "wind " + strconv.FormatUint(uint64(speed), 10) + " " + unit
+		if c.WindDirCompass != "" {
+			wind += " " + c.WindDirCompass
+		}
+		parts = append(parts, wind)
+	}
+	if c.Humidity != 0 {
+		parts = append(parts, "humidity "+strconv.FormatUint(uint64(c.Humidity), 10)+"%")
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,43 @@
+package wwo
+
+import (
+	"testing"
+)
+
+func TestConditionString(t *testing.T) {
+	var c = Condition{
+		Temp: 12, TempF: 54,
+		WeatherDesc: "Partly cloudy",
+		WindSpeed:   15, WindSpeedMiles: 9, WindDirCompass: "NW",
+		Humidity: 80,
+	}
+
+	if got, want := c.String(), "12°C, Partly cloudy, wind 15 km/h NW, humidity 80%"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	var cc = CurrentCondition{Condition: c, Temp: -2, TempF: 28}
+	if got, want := cc.String(), "-2°C, Partly cloudy, wind 15 km/h NW, humidity 80%"; got != want {
+		t.Errorf("CurrentCondition String() = %q, want %q", got, want)
+	}
+
+	if got, want := (Condition{}).String(), "0°C"; got != want {
+		t.Errorf("String() of no conditions = %q, want %q", got, want)
+	}
+}
+
+func TestAreaString(t *testing.T) {
+	for _, tt := range []struct {
+		area Area
+		want string
+	}{
+		{Area{Name: "London", Region: "City of London, Greater London", Country: "United Kingdom"}, "London, City of London, Greater London, United Kingdom"},
+		{Area{Name: "Monaco", Country: "Monaco"}, "Monaco, Monaco"},
+		{Area{Name: "Nowhere"}, "Nowhere"},
+		{Area{}, ""},
+	} {
+		if got := tt.area.String(); got != tt.want {
+			t.Errorf("%+v String() = %q, want %q", tt.area, got, tt.want)
+		}
+	}
+}
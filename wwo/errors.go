@@ -0,0 +1,132 @@
+package wwo
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// An HTTP error status returned by the API.
+//
+// The body is kept as it may explain the failure.
+type HTTPError struct {
+	StatusCode int    // Status code, e.g. 429
+	Status     string // Status line, e.g. "429 Too Many Requests"
+	Body       []byte // Body of the response
+}
+
+func (e *HTTPError) Error() string {
+	return "wwo: HTTP status " + e.Status
+}
+
+// An HTTP 429 status returned when the API is rate limiting requests.
+//
+// Matched by errors.As for an *HTTPError too.
+type RateLimitError struct {
+	HTTPError
+	RetryAfter time.Duration // Suggested wait from the Retry-After header, zero if not given
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return e.HTTPError.Error() + ", retry after " + e.RetryAfter.String()
+	}
+	return e.HTTPError.Error()
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return &e.HTTPError
+}
+
+// A response that is not in the format requested, such as an HTML error page.
+type FormatError struct {
+	Status      string // Status line, e.g. "200 OK"
+	ContentType string // Content type of the response
+	Snippet     string // Start of the body of the response
+}
+
+func (e *FormatError) Error() string {
+	return "wwo: unexpected " + e.ContentType + " response with status " + e.Status + ": " + strconv.Quote(e.Snippet)
+}
+
+// An error reported by the API in the body of its response.
+type APIError struct {
+	Message string // Description of the error
+	Type    string // Type of the error, if given
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Reports whether the error is ErrLocationNotFound, going by its message.
+func (e *APIError) Is(target error) bool {
+	return target == ErrLocationNotFound && strings.HasPrefix(e.Message, "Unable to find any matching weather location")
+}
+
+// Matched by errors.Is for an *APIError reporting that no location matched the query.
+var ErrLocationNotFound = errors.New("wwo: no matching location")
+
+// The error for a response's error message and type, nil if there was no message.
+func apiError(msg, typ *string) error {
+	if msg == nil {
+		return nil
+	}
+
+	var e = &APIError{Message: *msg}
+	if typ != nil {
+		e.Type = *typ
+	}
+	return e
+}
+
+// A decoded response, which may include an error reported by the API.
+type response interface {
+	err() error
+}
+
+func (o *Local) err() error      { return apiError(o.Error, o.ErrorType) }
+func (o *Marine) err() error     { return apiError(o.Error, o.ErrorType) }
+func (o *PastLocal) err() error  { return apiError(o.Error, o.ErrorType) }
+func (o *PastMarine) err() error { return apiError(o.Error, o.ErrorType) }
+func (o *Ski) err() error        { return apiError(o.Error, o.ErrorType) }
+func (o *TimeZone) err() error   { return apiError(o.Error, o.ErrorType) }
+func (o *Search) err() error     { return apiError(o.Error, o.ErrorType) }
+
+// Any response, decoded only as far as the error reported by the API.
+type anyResponse struct {
+	Error     *string `xml:"error>msg"`
+	ErrorType *string `xml:"error>type"`
+}
+
+func (o *anyResponse) err() error { return apiError(o.Error, o.ErrorType) }
+
+// Returned for a response larger than the WWO MaxResponseBytes.
+var ErrResponseTooLarge = errors.New("wwo: response too large")
+
+// Matched by errors.Is for a successful response with an empty body, as seen during maintenance.
+var ErrEmptyResponse = errors.New("wwo: empty response")
+
+// Returned by NewWWOFromEnv when the environment gives no API key.
+var ErrNoKey = errors.New("wwo: " + KeyEnv + " not set")
+
+// Matched by errors.Is for any *OptionError.
+var ErrInvalidOption = errors.New("wwo: invalid option")
+
+// An option given a value the API does not accept, detected before the request is sent.
+type OptionError struct {
+	Key   string // Option name, e.g. "tp"
+	Value string // The rejected value
+}
+
+func (e *OptionError) Error() string {
+	return "wwo: invalid value " + strconv.Quote(e.Value) + " for option " + e.Key
+}
+
+func (e *OptionError) Unwrap() error {
+	return ErrInvalidOption
+}
+
+// Returned for services the free plan does not offer.
+var ErrPremiumOnly = errors.New("wwo: service requires a premium plan")
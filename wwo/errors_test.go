@@ -0,0 +1,114 @@
+package wwo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHTTPError(t *testing.T) {
+	var w = testWWO(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(rw, "Too many requests today")
+	}))
+
+	l, err := w.GetLocal("London", nil)
+	if l != nil {
+		t.Errorf("result %+v for an error status", l)
+	}
+
+	var he *HTTPError
+	if !errors.As(err, &he) {
+		t.Fatalf("error %v, want an *HTTPError", err)
+	}
+	if he.StatusCode != 429 || he.Status != "429 Too Many Requests" || string(he.Body) != "Too many requests today" {
+		t.Errorf("HTTPError %d %q %q", he.StatusCode, he.Status, he.Body)
+	}
+	if err.Error() != "wwo: HTTP status 429 Too Many Requests" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	const msg = "Unable to find any matching weather location to the query submitted!"
+	var w = testWWO(t, respond(`<data><error><type>QueryError</type><msg>`+msg+`</msg></error></data>`))
+
+	l, err := w.GetLocal("Nowhere", nil)
+	if l == nil || l.Error == nil || *l.Error != msg {
+		t.Errorf("result %+v does not keep the error message", l)
+	}
+
+	var ae *APIError
+	if !errors.As(err, &ae) {
+		t.Fatalf("error %v, want an *APIError", err)
+	}
+	if ae.Message != msg || ae.Type != "QueryError" {
+		t.Errorf("APIError %+v", ae)
+	}
+	if err.Error() != msg {
+		t.Errorf("Error() = %q, want the message", err.Error())
+	}
+
+	var ok = testWWO(t, respond("<data></data>"))
+	if _, err := ok.GetLocal("London", nil); err != nil {
+		t.Errorf("error %v for a response without an error", err)
+	}
+}
+
+func TestErrLocationNotFound(t *testing.T) {
+	var w = testWWO(t, respond(notFoundXML))
+
+	_, err := w.GetLocal("Nowhere", nil)
+	if !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("error %v, want ErrLocationNotFound", err)
+	}
+
+	var ae *APIError
+	if !errors.As(err, &ae) || ae.Message != "Unable to find any matching weather location to the query submitted!" {
+		t.Errorf("error %v, want the APIError with the API's message", err)
+	}
+
+	if errors.Is(&APIError{Message: "API key has reached calls per day allowed limit."}, ErrLocationNotFound) {
+		t.Error("other API errors match ErrLocationNotFound")
+	}
+}
+
+func TestRawErrors(t *testing.T) {
+	var w = testWWO(t, respond(notFoundXML))
+
+	l, err := w.GetLocal("Nowhere", nil)
+	if !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("error %v, want ErrLocationNotFound", err)
+	}
+	if l == nil || l.Error == nil {
+		t.Errorf("result %+v, want it to have the error", l)
+	}
+
+	w.RawErrors = true
+	l, err = w.GetLocal("Nowhere", nil)
+	if err != nil {
+		t.Errorf("error %v with RawErrors, want none", err)
+	}
+	if l == nil || l.Error == nil || !strings.HasPrefix(*l.Error, "Unable to find") {
+		t.Errorf("result %+v with RawErrors, want it to have the error", l)
+	}
+
+	// GetCurrent's result has no Error field to leave it in.
+	if _, err := w.GetCurrent("Nowhere", nil); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("GetCurrent error %v with RawErrors, want ErrLocationNotFound", err)
+	}
+}
+
+func TestErrEmptyResponse(t *testing.T) {
+	var w = testWWO(t, respond(""))
+
+	_, err := w.GetLocal("London", nil)
+	if !errors.Is(err, ErrEmptyResponse) {
+		t.Errorf("error %v, want ErrEmptyResponse", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "200 OK") {
+		t.Errorf("error %v, want it to include the status", err)
+	}
+}
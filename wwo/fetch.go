@@ -5,65 +5,453 @@ This requires an API key, held by a WWO structure,
 which is then used to perform queries.
 
  var weather = WWO({"your-hex-api-key-goes-in-here!"})
- forecast, err := weather.GetLocal("London", map[string]string{})
+ forecast, err := weather.GetLocal("London", nil)
 
-The optional options passed in the map are documented with the various Get functions.
+The optional options passed in the map are documented with the various Get functions,
+a nil map may be passed when there are none.
 Each Get function returns a structure of the appropriate type and a possible error.
-That error will be set for any transport, unmashalling, or API errors,
+That error will be set for any transport, HTTP status, unmashalling, or API errors,
 depending on the type of error, including all API errors, the structure may also be filled in to some extent.
+HTTP error statuses are returned as an *HTTPError, or *RateLimitError for 429,
+responses that are not XML, or JSON, such as HTML error pages, as a *FormatError,
+and API errors as an *APIError,
+which is matched by errors.Is for ErrLocationNotFound when the location given was not found,
+unless RawErrors is set, when they are left in the Error field of the structure.
+Options with values outside those documented are rejected with an *OptionError before any request is made.
+The option _scheme, http or https, is not sent but overrides the scheme of the request,
+as Insecure does for all requests, http sending the API key in plain text.
+
+Responses are requested as XML unless the WWO Format is FormatJSON,
+either format being decoded into the same structures.
+Those structures carry their own json tags, with snake_case names that include units,
+so results can be encoded as JSON for other uses, which is not the API's own JSON format.
+Responses kept, as with KeepRaw, can be decoded again without a request by ParseLocal and the like.
+
+Requests are made with the WWO HTTPClient, whose Transport is used unchanged.
+Without one http.DefaultClient is used, which honours the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables,
+so a client given for use behind a proxy needs a Transport whose Proxy is set,
+for example to http.ProxyFromEnvironment or http.ProxyURL.
+
+A WWO may be used by many goroutines at once, once its fields are set,
+as the Get functions only read it, copying the options they are passed.
+Its Cache, Limiter, Logger, and hooks are shared by those goroutines, so must themselves be safe for concurrent use,
+as are those returned by NewMemoryCache and NewLimiter, and a *log.Logger.
 
 */
 package wwo
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
 	"encoding/xml"
 	"errors"
-	"io/ioutil"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// Version of this package, included in the default User-Agent.
+const Version = "0.1.0"
+
+// WorldWeatherOnline API plans, which are served from different paths.
+//
+// The free plan offers only local weather, marine, search, and time zone lookups,
+// the other Get functions requiring a premium plan,
+// and returning ErrPremiumOnly on the free plan without making a request.
+type Tier int
+
+const (
+	Premium Tier = iota // Served from /premium/v1/
+	Free                // Served from /free/v2/
+)
+
+// Whether service is offered on the plan.
+func (t Tier) offers(service string) bool {
+	switch service {
+	case "ski", "past-weather", "past-marine":
+		return t != Free
+	}
+	return true
+}
+
+func (t Tier) path() string {
+	if t == Free {
+		return "/free/v2/"
+	}
+	return "/premium/v1/"
+}
+
 // Essential information for WorldWeatherOnline lookups.
+//
+// It is safe for concurrent use by many goroutines,
+// provided its fields are not changed meanwhile.
 type WWO struct {
-	Key      string // API key
-	Insecure bool   // Use http rather than https
+	Key              string        // API key
+	Insecure         bool          // Use http rather than https, which sends the API key in plain text
+	HTTPClient       *http.Client  // Client used for requests, http.DefaultClient if nil
+	Timeout          time.Duration // Limit on the time taken by each request, or retry, none if zero
+	Format           Format        // Format of the responses requested, FormatXML if zero
+	Unit             Unit          // Preferred system of units for presenting conditions, Metric if zero
+	BaseURL          string        // URL the service names are appended to, overriding Insecure and Tier, if not empty
+	Tier             Tier          // Plan of the API key, Premium if zero
+	MaxRetries       int           // Number of times a request failing with a transport, 429, or 5xx error is retried
+	MaxRetryWait     time.Duration // Longest wait asked for by a 429 response that is retried after, rather than returning its error, a minute if zero
+	Limiter          Limiter       // Limits the rate of requests, including retries, if not nil
+	Cache            Cache         // Stores successful responses for reuse if not nil
+	CacheTTL         time.Duration // Time responses are kept in the Cache, indefinitely if zero
+	Concurrency      int           // Number of requests made at once by the batch functions, 4 if zero
+	KeepRaw          bool          // Keep the body of each response in the Raw field of its result
+	UserAgent        string        // User-Agent header sent with requests, "wwo-go/" and Version if empty
+	RawErrors        bool          // Leave errors reported by the API in the Error field of results rather than returning them, if they have one
+	Logger           Logger        // Logs the URL, with the API key redacted, status, and size of each response, if not nil
+	MaxResponseBytes int64         // Limit on the size of each response, after decompression, none if zero
+
+	// Delay before the given retry, counting from zero.
+	// If nil the delay starts at a second, doubling with each retry.
+	Backoff func(retry int) time.Duration
+
+	// Called with each request, including retries, just before it is sent, if not nil.
+	// The service is the last element of the request's URL path, e.g. "weather.ashx".
+	OnRequest func(req *http.Request)
+
+	// Called with the response to each request, or the error sending it, and the time taken, if not nil.
+	// The body is read by the WWO and must not be read here.
+	OnResponse func(resp *http.Response, err error, elapsed time.Duration)
 }
 
-func (w *WWO) fetch(service string, query map[string]string) ([]byte, error) {
-	var u url.URL
+// Fetch the response text from service for query, reporting whether it came from the Cache.
+func (w *WWO) fetch(service string, query map[string]string) ([]byte, cacheHit, error) {
+	return w.fetchContext(context.Background(), service, query)
+}
 
-	if w.Insecure {
-		u.Scheme = "http"
+// Fetch as with fetch, giving up when ctx is done, including between retries.
+func (w *WWO) fetchContext(ctx context.Context, service string, query map[string]string) ([]byte, cacheHit, error) {
+	u, err := w.requestURL(service, query)
+	if err != nil {
+		return nil, cacheHit{}, err
+	}
+
+	var key = u.String()
+	if w.Cache != nil {
+		if text, hit := cacheGet(w.Cache, key); hit.ok {
+			return text, hit, nil
+		}
+	}
+
+	setKey(u, w.Key)
+
+	var text []byte
+
+	for retry := 0; ; retry++ {
+		text, err = w.get(ctx, service, u.String())
+		if err == nil || retry >= w.MaxRetries || !retryable(err) || ctx.Err() != nil {
+			break
+		}
+
+		wait, ok := w.backoff(retry, err)
+		if !ok {
+			break
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return nil, cacheHit{}, ctx.Err()
+		}
+	}
+	if err != nil {
+		return nil, cacheHit{}, err
+	}
+
+	if w.Cache != nil && decode(w.Format, service, text, new(anyResponse)) == nil {
+		w.Cache.Set(key, text, w.CacheTTL)
+	}
+
+	return text, cacheHit{}, nil
+}
+
+// The URL a request to service for location with the options opt would be made to,
+// as by the Get function for the service, e.g. "weather" for GetLocal.
+// If redactKey is set the API key is replaced by REDACTED, so the URL may be shown.
+func (w *WWO) BuildURL(service, location string, opt map[string]string, redactKey bool) (*url.URL, error) {
+	u, err := w.requestURL(service, locationQuery(location, opt))
+	if err != nil {
+		return nil, err
+	}
+
+	if redactKey {
+		setKey(u, "REDACTED")
 	} else {
-		u.Scheme = "https"
+		setKey(u, w.Key)
+	}
+	return u, nil
+}
+
+// The URL for a request to service with query, without the API key.
+func (w *WWO) requestURL(service string, query map[string]string) (*url.URL, error) {
+	if !w.Tier.offers(service) {
+		return nil, ErrPremiumOnly
+	}
+
+	if err := validate(query); err != nil {
+		return nil, err
 	}
 
-	u.Host = "api.worldweatheronline.com"
-	u.Path = "/premium/v1/" + service + ".ashx"
+	var base = w.BaseURL
+
+	if base == "" {
+		if w.Insecure {
+			base = "http://"
+		} else {
+			base = "https://"
+		}
+		base += "api.worldweatheronline.com" + w.Tier.path()
+	}
+
+	u, err := url.Parse(strings.TrimSuffix(base, "/") + "/" + service + ".ashx")
+	if err != nil {
+		return nil, err
+	}
 
 	var values = make(url.Values)
 
-	values.Set("key", w.Key)
 	for k, v := range query {
+		if k == "_scheme" {
+			u.Scheme = v
+			continue
+		}
 		values.Set(k, v)
 	}
-	values.Set("format", "xml")
+	values.Set("format", w.Format.String())
 	u.RawQuery = values.Encode()
 
-	resp, err := http.Get(u.String())
+	return u, nil
+}
+
+// Add the API key to the query of u.
+func setKey(u *url.URL, key string) {
+	values := u.Query()
+	values.Set("key", key)
+	u.RawQuery = values.Encode()
+}
+
+// Make a single request to service for the body at u.
+func (w *WWO) get(ctx context.Context, service, u string) ([]byte, error) {
+	client := w.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	defer resp.Body.Close()
-	text, err := ioutil.ReadAll(resp.Body)
+	// Setting this stops the transport decompressing responses itself,
+	// so that is done here, whatever the client.
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	if w.UserAgent != "" {
+		req.Header.Set("User-Agent", w.UserAgent)
+	} else {
+		req.Header.Set("User-Agent", "wwo-go/"+Version)
+	}
+
+	if w.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), w.Timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	if w.Limiter != nil {
+		if err := w.Limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	if w.OnRequest != nil {
+		w.OnRequest(req)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if w.OnResponse != nil {
+		w.OnResponse(resp, err, time.Since(start))
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	defer resp.Body.Close()
+	body, err := decompress(resp)
+	if err != nil {
+		return nil, fmt.Errorf("wwo: reading %s response: %w", service, err)
+	}
+
+	if w.MaxResponseBytes > 0 {
+		body = io.LimitReader(body, w.MaxResponseBytes+1)
+	}
+
+	text, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("wwo: reading %s response: %w", service, err)
+	}
+	if w.MaxResponseBytes > 0 && int64(len(text)) > w.MaxResponseBytes {
+		return nil, ErrResponseTooLarge
+	}
+
+	if w.Logger != nil {
+		w.Logger.Printf("wwo: GET %s: %s, %d bytes", redact(u), resp.Status, len(text))
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &RateLimitError{
+			HTTPError{resp.StatusCode, resp.Status, text},
+			retryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+	if resp.StatusCode >= 400 {
+		return nil, &HTTPError{resp.StatusCode, resp.Status, text}
+	}
+
+	if len(bytes.TrimSpace(text)) == 0 {
+		return nil, fmt.Errorf("%w with status %s", ErrEmptyResponse, resp.Status)
+	}
+
+	if w.Format.mismatch(text) {
+		return nil, &FormatError{resp.Status, resp.Header.Get("Content-Type"), snippet(text)}
+	}
+
 	return text, nil
 }
 
+// The body of resp, decompressed according to its Content-Encoding.
+func decompress(resp *http.Response) (io.Reader, error) {
+	switch strings.ToLower(resp.Header.Get("Content-Encoding")) {
+	case "gzip":
+		return gzip.NewReader(resp.Body)
+	case "deflate":
+		return zlib.NewReader(resp.Body)
+	}
+	return resp.Body, nil
+}
+
+// The start of text, for inclusion in errors.
+func snippet(text []byte) string {
+	if len(text) > 200 {
+		return string(text[:200]) + "..."
+	}
+	return string(text)
+}
+
+// Whether a request that failed with err may succeed if made again.
+// Only rate limiting and server errors are retried, along with transport errors,
+// but not responses that are too large.
+func retryable(err error) bool {
+	var he *HTTPError
+	if errors.As(err, &he) {
+		return he.StatusCode == http.StatusTooManyRequests || he.StatusCode >= 500
+	}
+
+	var fe *FormatError
+	return !errors.As(err, &fe) && err != ErrResponseTooLarge
+}
+
+// The delay before the given retry, counting from zero, after err.
+// A wait suggested by the API when rate limiting takes precedence,
+// unless it is longer than MaxRetryWait, when ok is false as the request is not to be retried.
+func (w *WWO) backoff(retry int, err error) (d time.Duration, ok bool) {
+	var rle *RateLimitError
+	if errors.As(err, &rle) && rle.RetryAfter > 0 {
+		max := w.MaxRetryWait
+		if max <= 0 {
+			max = time.Minute
+		}
+		return rle.RetryAfter, rle.RetryAfter <= max
+	}
+
+	if w.Backoff != nil {
+		return w.Backoff(retry), true
+	}
+	return time.Second << uint(retry), true
+}
+
+// The wait given by a Retry-After header in either seconds or as a date, zero if none.
+func retryAfter(h string) time.Duration {
+	if h == "" {
+		return 0
+	}
+
+	if s, err := strconv.Atoi(h); err == nil {
+		if s < 0 {
+			return 0
+		}
+		return time.Duration(s) * time.Second
+	}
+
+	if t, err := http.ParseTime(h); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+// Decode the response text from service into o, which is converted first if requested as JSON,
+// returning the error the API reported in it if any.
+// Errors decoding it are wrapped with the service and the start of the text.
+// With RawErrors set the error the API reported is left in o rather than returned.
+func (w *WWO) decode(service string, text []byte, o response) error {
+	err := decode(w.Format, service, text, o)
+
+	var ae *APIError
+	if w.RawErrors && errors.As(err, &ae) {
+		return nil
+	}
+	return err
+}
+
+// Decode the response text from service in format f into o, as with the WWO decode method.
+func decode(f Format, service string, text []byte, o response) error {
+	var body = text
+
+	if f == FormatJSON {
+		var err error
+		if body, err = jsonToXML(text); err != nil {
+			return fmt.Errorf("wwo: decoding %s response %q: %w", service, snippet(text), err)
+		}
+	}
+
+	if err := xml.Unmarshal(body, o); err != nil {
+		return fmt.Errorf("wwo: decoding %s response %q: %w", service, snippet(text), err)
+	}
+
+	return o.err()
+}
+
+// Build the query for location from the caller's options, leaving them unmodified.
+// A nil opt is treated as no options.
+func locationQuery(location string, opt map[string]string) map[string]string {
+	var q = make(map[string]string, len(opt)+2)
+
+	for k, v := range opt {
+		q[k] = v
+	}
+	q["q"] = location
+	q["date_format"] = ""
+
+	return q
+}
+
 // Fetch a local forecast for location.
 //
 // Supported options are (defaults marked with *):
@@ -75,28 +463,108 @@ func (w *WWO) fetch(service string, query map[string]string) ([]byte, error) {
 //   fx24             Include tp-hourly forecasts (*yes, no)
 //   includelocation  Include nearest location information (yes, *no)
 //   tp               Number of hours in detailed forecast (1, *3, 6, 12, 24)
+//   aqi              Include air quality (yes, *no)
+//   alerts           Include severe weather alerts (yes, *no)
+//   show_comments    Include forecast commentary (yes, *no)
+//   extra            Comma separated extra fields to include, see Extras
 func (w *WWO) GetLocal(location string, opt map[string]string) (*Local, error) {
-	opt["q"] = location
-	opt["date_format"] = ""
-
-	text, err := w.fetch("weather", opt)
+	query := locationQuery(location, opt)
+	text, hit, err := w.fetch("weather", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var o *Local = new(Local)
-	err = xml.Unmarshal(text, o)
-	if err != nil {
-		return o, err
+	if w.KeepRaw {
+		o.Raw = text
 	}
+	o.FromCache, o.CachedAt = hit.ok, hit.at
+	o.Options = query
 
-	if o.Error != nil {
-		return o, errors.New(*o.Error)
+	if err := w.decode("weather", text, o); err != nil {
+		return o, err
 	}
 
 	return o, nil
 }
 
+// Fetch a local forecast for location using typed options.
+func (w *WWO) GetLocalOpts(location string, o LocalOptions) (*Local, error) {
+	return w.GetLocal(location, o.Map())
+}
+
+// Fetch only the current conditions for location with the nearest area,
+// leaving out the forecast and monthly averages.
+//
+// The options are those of GetLocal, the ones for the forecast having no effect.
+func (w *WWO) GetCurrent(location string, opt map[string]string) (*Current, error) {
+	o, err := w.GetLocal(location, override(opt, map[string]string{
+		"num_of_days":     "0",
+		"fx":              "no",
+		"cc":              "yes",
+		"mca":             "no",
+		"includelocation": "yes",
+	}))
+	if o == nil {
+		return nil, err
+	}
+	if err == nil {
+		err = o.err() // Returned even with RawErrors, as there is no Error field to leave it in
+	}
+
+	return &Current{CurrentCondition: o.Current, Area: o.Area}, err
+}
+
+// Fetch only the monthly climate averages for location, leaving out the forecast and current conditions.
+//
+// The options are those of GetLocal, the ones for the forecast having no effect.
+func (w *WWO) GetClimate(location string, opt map[string]string) ([]ClimateAverage, error) {
+	o, err := w.GetLocal(location, override(opt, map[string]string{
+		"num_of_days": "0",
+		"fx":          "no",
+		"cc":          "no",
+		"mca":         "yes",
+	}))
+	if o == nil {
+		return nil, err
+	}
+	if err == nil {
+		err = o.err() // Returned even with RawErrors, as there is no Error field to leave it in
+	}
+
+	return o.Climate, err
+}
+
+// Fetch the forecast for location for the day of d, in d's location.
+//
+// The options are those of GetLocal, the ones choosing the days and leaving out the forecast having no effect.
+// An error is returned if there is no forecast for the day, as when it is out of the forecast's range.
+func (w *WWO) GetLocalForDate(location string, d time.Time, opt map[string]string) (*ForecastWeather, error) {
+	date := d.Format("2006-01-02")
+
+	o, err := w.GetLocal(location, override(opt, map[string]string{
+		"date":        date,
+		"num_of_days": "1",
+		"fx":          "yes",
+		"cc":          "no",
+		"mca":         "no",
+	}))
+	if err == nil {
+		err = o.err() // Returned even with RawErrors, as there is no Error field to leave it in
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range o.Weather {
+		if time.Time(o.Weather[i].Date).Format("2006-01-02") == date {
+			return &o.Weather[i], nil
+		}
+	}
+
+	return nil, errors.New("wwo: no forecast for " + date)
+}
+
 // Fetch a marine forecast for location.
 //
 // Supported options are (defaults marked with *):
@@ -104,22 +572,21 @@ func (w *WWO) GetLocal(location string, opt map[string]string) (*Local, error) {
 //   tp    Number of hours in detailed forecast (1, *3, 6, 12, 24)
 //   tide  Include tide information (yes, *no)
 func (w *WWO) GetMarine(location string, opt map[string]string) (*Marine, error) {
-	opt["q"] = location
-	opt["date_format"] = ""
-
-	text, err := w.fetch("marine", opt)
+	query := locationQuery(location, opt)
+	text, hit, err := w.fetch("marine", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var o *Marine = new(Marine)
-	err = xml.Unmarshal(text, o)
-	if err != nil {
-		return o, err
+	if w.KeepRaw {
+		o.Raw = text
 	}
+	o.FromCache, o.CachedAt = hit.ok, hit.at
+	o.Options = query
 
-	if o.Error != nil {
-		return o, errors.New(*o.Error)
+	if err := w.decode("marine", text, o); err != nil {
+		return o, err
 	}
 
 	return o, nil
@@ -132,22 +599,21 @@ func (w *WWO) GetMarine(location string, opt map[string]string) (*Marine, error)
 //   date             Start date of forecast (today, *tomorrow, YYYY-mm-dd)
 //   includelocation  Include nearest location information (yes, *no)
 func (w *WWO) GetSki(location string, opt map[string]string) (*Ski, error) {
-	opt["q"] = location
-	opt["date_format"] = ""
-
-	text, err := w.fetch("ski", opt)
+	query := locationQuery(location, opt)
+	text, hit, err := w.fetch("ski", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var o *Ski = new(Ski)
-	err = xml.Unmarshal(text, o)
-	if err != nil {
-		return o, err
+	if w.KeepRaw {
+		o.Raw = text
 	}
+	o.FromCache, o.CachedAt = hit.ok, hit.at
+	o.Options = query
 
-	if o.Error != nil {
-		return o, errors.New(*o.Error)
+	if err := w.decode("ski", text, o); err != nil {
+		return o, err
 	}
 
 	return o, nil
@@ -156,27 +622,30 @@ func (w *WWO) GetSki(location string, opt map[string]string) (*Ski, error) {
 // Fetch historical local weather information for location.
 //
 // Supported options are (defaults marked with *):
-//   date             Start date (YYYY-mm-dd)
-//   enddate          End date (YYYY-mm-dd)
+//   date             Start date (YYYY-mm-dd), required
+//   enddate          End date (YYYY-mm-dd), in the same month
 //   includelocation  Include nearest location information (yes, *no)
 //   tp               Number of hours in detailed forecast (1, *3, 6, 12, 24)
 func (w *WWO) GetPastLocal(location string, opt map[string]string) (*PastLocal, error) {
-	opt["q"] = location
-	opt["date_format"] = ""
+	if err := validatePast(w.Tier, opt); err != nil {
+		return nil, err
+	}
 
-	text, err := w.fetch("past-weather", opt)
+	query := locationQuery(location, opt)
+	text, hit, err := w.fetch("past-weather", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var o *PastLocal = new(PastLocal)
-	err = xml.Unmarshal(text, o)
-	if err != nil {
-		return o, err
+	if w.KeepRaw {
+		o.Raw = text
 	}
+	o.FromCache, o.CachedAt = hit.ok, hit.at
+	o.Options = query
 
-	if o.Error != nil {
-		return o, errors.New(*o.Error)
+	if err := w.decode("past-weather", text, o); err != nil {
+		return o, err
 	}
 
 	return o, nil
@@ -185,27 +654,30 @@ func (w *WWO) GetPastLocal(location string, opt map[string]string) (*PastLocal,
 // Fetch historical marine weather information for location.
 //
 // Supported options are (defaults marked with *):
-//   date     Start date (YYYY-mm-dd)
-//   enddate  End date (YYYY-mm-dd)
+//   date     Start date (YYYY-mm-dd), required
+//   enddate  End date (YYYY-mm-dd), in the same month
 //   tp       Number of hours in detailed forecast (1, *3, 6, 12, 24)
 //   tide     Include tide information (yes, *no)
 func (w *WWO) GetPastMarine(location string, opt map[string]string) (*PastMarine, error) {
-	opt["q"] = location
-	opt["date_format"] = ""
+	if err := validatePast(w.Tier, opt); err != nil {
+		return nil, err
+	}
 
-	text, err := w.fetch("past-marine", opt)
+	query := locationQuery(location, opt)
+	text, hit, err := w.fetch("past-marine", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var o *PastMarine = new(PastMarine)
-	err = xml.Unmarshal(text, o)
-	if err != nil {
-		return o, err
+	if w.KeepRaw {
+		o.Raw = text
 	}
+	o.FromCache, o.CachedAt = hit.ok, hit.at
+	o.Options = query
 
-	if o.Error != nil {
-		return o, errors.New(*o.Error)
+	if err := w.decode("past-marine", text, o); err != nil {
+		return o, err
 	}
 
 	return o, nil
@@ -219,47 +691,56 @@ func (w *WWO) GetPastMarine(location string, opt map[string]string) (*PastMarine
 //   popular         Include only popular locations (yes, *no)
 //   wct             Limit locations to type (ski, cricket, football, golf, fishing)
 func (w *WWO) GetSearch(location string, opt map[string]string) (*Search, error) {
-	opt["q"] = location
-	opt["date_format"] = ""
+	return w.GetSearchContext(context.Background(), location, opt)
+}
 
-	text, err := w.fetch("search", opt)
+// Look up locations as with GetSearch, giving up when ctx is done.
+func (w *WWO) GetSearchContext(ctx context.Context, location string, opt map[string]string) (*Search, error) {
+	query := locationQuery(location, opt)
+	text, hit, err := w.fetchContext(ctx, "search", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var o *Search = new(Search)
-	err = xml.Unmarshal(text, o)
-	if err != nil {
-		return o, err
+	if w.KeepRaw {
+		o.Raw = text
 	}
+	o.FromCache, o.CachedAt = hit.ok, hit.at
+	o.Options = query
 
-	if o.Error != nil {
-		return o, errors.New(*o.Error)
+	if err := w.decode("search", text, o); err != nil {
+		return o, err
 	}
 
 	return o, nil
 }
 
-// Look up time zone information for location.
+// Look up locations using typed options.
+func (w *WWO) GetSearchOpts(location string, o SearchOptions) (*Search, error) {
+	return w.GetSearch(location, o.Map())
+}
+
+// Look up time zone information for location,
+// giving its offset from UTC, local time, and zone name, from which Zone.IsDST is found.
 //
 // No supported options at the moment.
 func (w *WWO) GetTimeZone(location string, opt map[string]string) (*TimeZone, error) {
-	opt["q"] = location
-	opt["date_format"] = ""
-
-	text, err := w.fetch("tz", opt)
+	query := locationQuery(location, opt)
+	text, hit, err := w.fetch("tz", query)
 	if err != nil {
 		return nil, err
 	}
 
 	var o *TimeZone = new(TimeZone)
-	err = xml.Unmarshal(text, o)
-	if err != nil {
-		return o, err
+	if w.KeepRaw {
+		o.Raw = text
 	}
+	o.FromCache, o.CachedAt = hit.ok, hit.at
+	o.Options = query
 
-	if o.Error != nil {
-		return o, errors.New(*o.Error)
+	if err := w.decode("tz", text, o); err != nil {
+		return o, err
 	}
 
 	return o, nil
@@ -0,0 +1,735 @@
+package wwo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	_ "time/tzdata"
+)
+
+// A WWO making requests to a test server with handler.
+func testWWO(t *testing.T, handler http.Handler) *WWO {
+	s := httptest.NewServer(handler)
+	t.Cleanup(s.Close)
+	return &WWO{Key: "k", BaseURL: s.URL}
+}
+
+// A handler answering every request with body.
+func respond(body string) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(rw, body)
+	}
+}
+
+// A handler answering every request with body, recording the URL of each.
+type recorder struct {
+	body string
+
+	mu   sync.Mutex
+	urls []*url.URL
+}
+
+func (h *recorder) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	h.urls = append(h.urls, r.URL)
+	h.mu.Unlock()
+
+	fmt.Fprint(rw, h.body)
+}
+
+// The URL of the last request, nil if there were none.
+func (h *recorder) last() *url.URL {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.urls) == 0 {
+		return nil
+	}
+	return h.urls[len(h.urls)-1]
+}
+
+// A RoundTripper answering every request with the same body, recording the URLs requested.
+type cannedTransport struct {
+	body string
+
+	mu   sync.Mutex
+	urls []string
+}
+
+func (t *cannedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.urls = append(t.urls, req.URL.String())
+	t.mu.Unlock()
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+// The number of requests made.
+func (t *cannedTransport) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.urls)
+}
+
+const currentXML = `<data><request><type>City</type><query>London, United Kingdom</query></request>` +
+	`<current_condition><observation_time>12:15 PM</observation_time><temp_C>12</temp_C><temp_F>54</temp_F>` +
+	`<weatherCode>116</weatherCode><weatherDesc>Partly cloudy</weatherDesc></current_condition></data>`
+
+func TestHTTPClient(t *testing.T) {
+	var rt = &cannedTransport{body: currentXML}
+	var w = &WWO{Key: "k", HTTPClient: &http.Client{Transport: rt}}
+
+	l, err := w.GetLocal("London", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rt.count() != 1 {
+		t.Fatalf("%d requests made with the client, want 1", rt.count())
+	}
+	if l.Current.Temp != 12 || l.Current.WeatherDesc != "Partly cloudy" {
+		t.Errorf("current conditions %+v not decoded from the canned body", l.Current)
+	}
+	if !strings.HasPrefix(rt.urls[0], "https://api.worldweatheronline.com/premium/v1/weather.ashx?") {
+		t.Errorf("requested %s", rt.urls[0])
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	var w = testWWO(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	w.Timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	_, err := w.GetLocal("London", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error %v, want a deadline exceeded error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("request took %v despite the timeout", elapsed)
+	}
+}
+
+func TestOptionsUnchanged(t *testing.T) {
+	var w = testWWO(t, respond(currentXML))
+	var opt = map[string]string{"tp": "3"}
+
+	for _, location := range []string{"London", "Paris"} {
+		if _, err := w.GetLocal(location, opt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(opt) != 1 || opt["tp"] != "3" {
+		t.Errorf("options changed to %v", opt)
+	}
+}
+
+func TestNilOptions(t *testing.T) {
+	var w = testWWO(t, respond("<data></data>"))
+
+	for name, get := range map[string]func() error{
+		"GetLocal":      func() error { _, err := w.GetLocal("London", nil); return err },
+		"GetCurrent":    func() error { _, err := w.GetCurrent("London", nil); return err },
+		"GetClimate":    func() error { _, err := w.GetClimate("London", nil); return err },
+		"GetMarine":     func() error { _, err := w.GetMarine("London", nil); return err },
+		"GetSki":        func() error { _, err := w.GetSki("London", nil); return err },
+		"GetPastLocal":  func() error { _, err := w.GetPastLocal("London", nil); return err },
+		"GetPastMarine": func() error { _, err := w.GetPastMarine("London", nil); return err },
+		"GetSearch":     func() error { _, err := w.GetSearch("London", nil); return err },
+		"GetTimeZone":   func() error { _, err := w.GetTimeZone("London", nil); return err },
+	} {
+		err := get()
+
+		// Past weather needs a date, so fails without one, but must not panic.
+		if strings.HasPrefix(name, "GetPast") {
+			if !errors.Is(err, ErrInvalidOption) {
+				t.Errorf("%s with nil options: error %v, want an invalid date", name, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s with nil options: %v", name, err)
+		}
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	var h = &recorder{body: currentXML}
+	var w = testWWO(t, h)
+	w.BaseURL += "/premium/v1/"
+
+	if _, err := w.GetLocal("London", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	u := h.last()
+	if u == nil {
+		t.Fatal("no request made to the BaseURL")
+	}
+	if u.Path != "/premium/v1/weather.ashx" {
+		t.Errorf("requested path %s, want /premium/v1/weather.ashx", u.Path)
+	}
+	if q := u.Query(); q.Get("q") != "London" || q.Get("key") != "k" {
+		t.Errorf("requested query %s", u.RawQuery)
+	}
+}
+
+func TestFreeTier(t *testing.T) {
+	var rt = &cannedTransport{body: currentXML}
+	var w = &WWO{Key: "k", HTTPClient: &http.Client{Transport: rt}}
+
+	for _, tier := range []Tier{Premium, Free} {
+		w.Tier = tier
+		if _, err := w.GetLocal("London", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !strings.Contains(rt.urls[0], "/premium/v1/weather.ashx") {
+		t.Errorf("premium request to %s", rt.urls[0])
+	}
+	if !strings.Contains(rt.urls[1], "/free/v2/weather.ashx") {
+		t.Errorf("free request to %s", rt.urls[1])
+	}
+
+	if _, err := w.GetSki("Zermatt", nil); err != ErrPremiumOnly {
+		t.Errorf("GetSki on the free plan: error %v, want ErrPremiumOnly", err)
+	}
+	if _, err := w.GetPastLocal("London", map[string]string{"date": "2024-06-01"}); err != ErrPremiumOnly {
+		t.Errorf("GetPastLocal on the free plan: error %v, want ErrPremiumOnly", err)
+	}
+	if rt.count() != 2 {
+		t.Errorf("%d requests made, want none for premium services on the free plan", rt.count()-2)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var calls atomic.Int32
+
+	var w = testWWO(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			http.Error(rw, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(rw, currentXML)
+	}))
+	w.MaxRetries = 3
+	w.Backoff = func(retry int) time.Duration { return time.Millisecond }
+
+	l, err := w.GetLocal("London", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Current.Temp != 12 {
+		t.Errorf("Current.Temp = %d, want 12", l.Current.Temp)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("%d requests made, want 3", n)
+	}
+
+	// Without retries the first failure is returned.
+	calls.Store(0)
+	w.MaxRetries = 0
+
+	var he *HTTPError
+	if _, err := w.GetLocal("London", nil); !errors.As(err, &he) || he.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("error %v, want a 503 HTTPError", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+
+	var w = testWWO(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		rw.Header().Set("Retry-After", "30")
+		http.Error(rw, "slow down", http.StatusTooManyRequests)
+	}))
+
+	_, err := w.GetLocal("London", nil)
+
+	var rle *RateLimitError
+	if !errors.As(err, &rle) {
+		t.Fatalf("error %v, want a RateLimitError", err)
+	}
+	if rle.RetryAfter != 30*time.Second {
+		t.Errorf("RetryAfter = %v, want 30s", rle.RetryAfter)
+	}
+
+	// A wait longer than MaxRetryWait is not slept through.
+	calls.Store(0)
+	w.MaxRetries = 1
+	w.MaxRetryWait = 10 * time.Second
+
+	start := time.Now()
+	if _, err := w.GetLocal("London", nil); !errors.As(err, &rle) {
+		t.Errorf("error %v, want a RateLimitError", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("waited %v for a retry beyond MaxRetryWait", elapsed)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("%d requests made, want 1", n)
+	}
+
+	if d := retryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("retryAfter of a date an hour away = %v", d)
+	}
+}
+
+func TestKeepRaw(t *testing.T) {
+	var w = testWWO(t, respond(currentXML))
+
+	l, err := w.GetLocal("London", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Raw != nil {
+		t.Errorf("Raw kept without KeepRaw")
+	}
+
+	w.KeepRaw = true
+	if l, err = w.GetLocal("London", nil); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(l.Raw, []byte(currentXML)) {
+		t.Errorf("Raw = %q, want the body served", l.Raw)
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	var w = testWWO(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(rw, "<!DOCTYPE html><html><body>Service Unavailable</body></html>")
+	}))
+
+	_, err := w.GetLocal("London", nil)
+
+	var fe *FormatError
+	if !errors.As(err, &fe) {
+		t.Fatalf("error %v, want a FormatError", err)
+	}
+	if fe.ContentType != "text/html" || !strings.Contains(fe.Snippet, "Service Unavailable") {
+		t.Errorf("FormatError = %+v", fe)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	var mu sync.Mutex
+	var agent string
+
+	var w = testWWO(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		agent = r.UserAgent()
+		mu.Unlock()
+		fmt.Fprint(rw, currentXML)
+	}))
+
+	if _, err := w.GetLocal("London", nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(agent, "wwo-go/") {
+		t.Errorf("User-Agent %q, want the default", agent)
+	}
+
+	w.UserAgent = "forecaster/2.0"
+	if _, err := w.GetLocal("London", nil); err != nil {
+		t.Fatal(err)
+	}
+	if agent != "forecaster/2.0" {
+		t.Errorf("User-Agent %q, want forecaster/2.0", agent)
+	}
+}
+
+func TestGetCurrent(t *testing.T) {
+	var h = &recorder{body: `<data><current_condition><observation_time>12:15 PM</observation_time><temp_C>12</temp_C></current_condition>` +
+		`<nearest_area><areaName>London</areaName><timezone><utcOffset>1.0</utcOffset></timezone></nearest_area></data>`}
+	var w = testWWO(t, h)
+
+	c, err := w.GetCurrent("London", map[string]string{"num_of_days": "3", "aqi": "yes"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := h.last().Query()
+	for k, v := range map[string]string{"num_of_days": "0", "fx": "no", "cc": "yes", "mca": "no", "includelocation": "yes", "aqi": "yes"} {
+		if q.Get(k) != v {
+			t.Errorf("%s=%q sent, want %q", k, q.Get(k), v)
+		}
+	}
+
+	if c.Temp != 12 || !c.Has("Temp") || c.Area.Name != "London" || c.Area.Zone == nil {
+		t.Errorf("current conditions %+v in area %+v", c.CurrentCondition, c.Area)
+	}
+	if _, offset := c.ObservationTime().Zone(); offset != 3600 {
+		t.Errorf("observation time not in the area's zone: %v", c.ObservationTime())
+	}
+}
+
+func TestGzip(t *testing.T) {
+	var gotEncoding atomic.Value
+
+	var w = testWWO(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		gotEncoding.Store(r.Header.Get("Accept-Encoding"))
+
+		rw.Header().Set("Content-Encoding", "gzip")
+		z := gzip.NewWriter(rw)
+		fmt.Fprint(z, currentXML)
+		z.Close()
+	}))
+
+	l, err := w.GetLocal("London", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Current.Temp != 12 {
+		t.Errorf("Current.Temp = %d, want 12 from the compressed body", l.Current.Temp)
+	}
+	if enc, _ := gotEncoding.Load().(string); !strings.Contains(enc, "gzip") {
+		t.Errorf("Accept-Encoding %q sent", enc)
+	}
+}
+
+func TestProxy(t *testing.T) {
+	var mu sync.Mutex
+	var proxied []string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		proxied = append(proxied, r.URL.String())
+		mu.Unlock()
+		fmt.Fprint(rw, currentXML)
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var w = &WWO{
+		Key:        "k",
+		BaseURL:    "http://api.worldweatheronline.invalid/premium/v1/",
+		HTTPClient: &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}},
+	}
+
+	l, err := w.GetLocal("London", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Current.Temp != 12 {
+		t.Errorf("Current.Temp = %d, want 12 from the proxy", l.Current.Temp)
+	}
+	if len(proxied) != 1 || !strings.HasPrefix(proxied[0], "http://api.worldweatheronline.invalid/premium/v1/weather.ashx?") {
+		t.Errorf("proxy received %v", proxied)
+	}
+}
+
+// Climate averages for each month, the average temperature being the month's number.
+func climateXML() string {
+	var b strings.Builder
+	b.WriteString(`<data><nearest_area><areaName>London</areaName></nearest_area><ClimateAverages>`)
+	for m := time.January; m <= time.December; m++ {
+		fmt.Fprintf(&b, `<month><index>%d</index><name>%s</name><avgTemp>%d</avgTemp></month>`, m, m, m)
+	}
+	b.WriteString(`</ClimateAverages></data>`)
+	return b.String()
+}
+
+func TestGetClimate(t *testing.T) {
+	var h = &recorder{body: climateXML()}
+	var w = testWWO(t, h)
+
+	climate, err := w.GetClimate("London", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := h.last().Query()
+	for k, v := range map[string]string{"num_of_days": "0", "fx": "no", "cc": "no", "mca": "yes"} {
+		if q.Get(k) != v {
+			t.Errorf("%s=%q sent, want %q", k, q.Get(k), v)
+		}
+	}
+
+	if len(climate) != 12 {
+		t.Fatalf("%d months of climate averages, want 12", len(climate))
+	}
+	for i, c := range climate {
+		if c.Index != uint(i+1) || c.Name != time.Month(i+1).String() || c.Temp != float64(i+1) {
+			t.Errorf("Climate[%d] = %+v", i, c)
+		}
+	}
+}
+
+func TestVersion(t *testing.T) {
+	if Version == "" {
+		t.Fatal("Version is empty")
+	}
+
+	var mu sync.Mutex
+	var agent string
+
+	var w = testWWO(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		agent = r.UserAgent()
+		mu.Unlock()
+		fmt.Fprint(rw, currentXML)
+	}))
+
+	if _, err := w.GetLocal("London", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !strings.Contains(agent, Version) {
+		t.Errorf("User-Agent %q, want it to include %s", agent, Version)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	var w = testWWO(t, respond(currentXML))
+
+	var requested, responded []string
+	w.OnRequest = func(req *http.Request) {
+		requested = append(requested, path.Base(req.URL.Path))
+	}
+	w.OnResponse = func(resp *http.Response, err error, elapsed time.Duration) {
+		if err != nil || resp.StatusCode != http.StatusOK || elapsed <= 0 {
+			t.Errorf("OnResponse(%v, %v, %v), want a 200 response", resp, err, elapsed)
+		}
+		responded = append(responded, path.Base(resp.Request.URL.Path))
+	}
+
+	if _, err := w.GetLocal("London", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.GetMarine("50.8,-0.1", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"weather.ashx", "marine.ashx"}
+	if fmt.Sprint(requested) != fmt.Sprint(want) {
+		t.Errorf("OnRequest called for %v, want %v", requested, want)
+	}
+	if fmt.Sprint(responded) != fmt.Sprint(want) {
+		t.Errorf("OnResponse called for %v, want %v", responded, want)
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	var w = testWWO(t, respond(currentXML))
+
+	w.MaxResponseBytes = int64(len(currentXML))
+	if _, err := w.GetLocal("London", nil); err != nil {
+		t.Errorf("response of the maximum size: %v", err)
+	}
+
+	w.MaxResponseBytes = int64(len(currentXML)) - 1
+	if _, err := w.GetLocal("London", nil); err != ErrResponseTooLarge {
+		t.Errorf("response over the maximum size: error %v, want ErrResponseTooLarge", err)
+	}
+}
+
+func TestReadError(t *testing.T) {
+	// A body cut short of its Content-Length.
+	var w = testWWO(t, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Length", fmt.Sprint(len(currentXML)))
+		fmt.Fprint(rw, currentXML[:len(currentXML)/2])
+	}))
+
+	_, err := w.GetMarine("50.8,-0.1", nil)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("error %v, want io.ErrUnexpectedEOF", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "reading marine response") {
+		t.Errorf("error %v, want it to name the service", err)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	var truncated = currentXML[:len(currentXML)/2]
+	var w = testWWO(t, respond(truncated))
+
+	_, err := w.GetMarine("50.8,-0.1", nil)
+
+	var se *xml.SyntaxError
+	if !errors.As(err, &se) {
+		t.Fatalf("error %v, want an xml.SyntaxError", err)
+	}
+	if s := err.Error(); !strings.Contains(s, "decoding marine response") || !strings.Contains(s, truncated[:40]) {
+		t.Errorf("error %q, want it to name the service and include the body", s)
+	}
+}
+
+func TestIncludeLocation(t *testing.T) {
+	var h = &recorder{body: `<data><nearest_area><areaName>City of London</areaName><country>United Kingdom</country>` +
+		`<region>Greater London</region><latitude>51.517</latitude><longitude>-0.106</longitude><population>7556900</population>` +
+		`<distance_miles>1.2</distance_miles><weatherUrl>https://www.worldweatheronline.com/v2/weather.aspx?q=51.5171,-0.1062</weatherUrl>` +
+		`<timezone><utcOffset>1.0</utcOffset></timezone></nearest_area></data>`}
+	var w = testWWO(t, h)
+
+	l, err := w.GetLocalOpts("51.5,-0.1", LocalOptions{IncludeLocation: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := h.last().Query().Get("includelocation"); got != "yes" {
+		t.Errorf("includelocation=%q sent, want yes", got)
+	}
+	a := l.Area
+	if a.Name != "City of London" || a.Country != "United Kingdom" || a.Region != "Greater London" ||
+		a.Latitude != 51.517 || a.Longitude != -0.106 || a.Population != 7556900 || a.DistanceMI != 1.2 ||
+		a.WeatherURL == "" || a.Zone == nil || a.Zone.Offset != 1 {
+		t.Errorf("Area = %+v", a)
+	}
+}
+
+func TestGetLocalForDate(t *testing.T) {
+	// Only 2024-06-01 is in the forecast's range.
+	var h = &recorder{body: `<data><weather><date>2024-06-01</date><maxtempC>21</maxtempC></weather></data>`}
+	var w = testWWO(t, h)
+
+	d, err := w.GetLocalForDate("London", time.Date(2024, 6, 1, 15, 0, 0, 0, time.UTC), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := h.last().Query()
+	for k, v := range map[string]string{"date": "2024-06-01", "num_of_days": "1", "fx": "yes"} {
+		if q.Get(k) != v {
+			t.Errorf("%s=%q sent, want %q", k, q.Get(k), v)
+		}
+	}
+	if d.MaxTemp != 21 || time.Time(d.Date).Format("2006-01-02") != "2024-06-01" {
+		t.Errorf("forecast for %v with MaxTemp %d, want 2024-06-01 with 21", d.Date, d.MaxTemp)
+	}
+
+	if d, err := w.GetLocalForDate("London", time.Date(2024, 7, 1, 15, 0, 0, 0, time.UTC), nil); err == nil {
+		t.Errorf("forecast for %v returned for 2024-07-01, want an error", d.Date)
+	}
+}
+
+func TestResultOptions(t *testing.T) {
+	var w = testWWO(t, respond(`<data></data>`))
+
+	for _, tt := range []struct {
+		opt  map[string]string
+		want int
+	}{
+		{nil, 3},
+		{map[string]string{"tp": "1"}, 1},
+		{map[string]string{"tp": "24"}, 24},
+	} {
+		l, err := w.GetLocal("London", tt.opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l.TP() != tt.want || l.Options["tp"] != tt.opt["tp"] || l.Options["q"] != "London" {
+			t.Errorf("%v: TP() = %d, Options %v, want %d", tt.opt, l.TP(), l.Options, tt.want)
+		}
+
+		m, err := w.GetMarine("50.8,-0.1", tt.opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.TP() != tt.want {
+			t.Errorf("%v: marine TP() = %d, want %d", tt.opt, m.TP(), tt.want)
+		}
+	}
+}
+
+func TestBuildURL(t *testing.T) {
+	var h = &recorder{body: currentXML}
+	var w = testWWO(t, h)
+	var opt = map[string]string{"tp": "3"}
+
+	if _, err := w.GetLocal("London", opt); err != nil {
+		t.Fatal(err)
+	}
+	sent := h.last()
+
+	u, err := w.BuildURL("weather", "London", opt, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Path != "/weather.ashx" || u.Path != sent.Path {
+		t.Errorf("path %s, want /weather.ashx as sent", u.Path)
+	}
+	if want := "date_format=&format=xml&key=k&q=London&tp=3"; u.RawQuery != want || u.RawQuery != sent.RawQuery {
+		t.Errorf("query %s, want %s as sent", u.RawQuery, want)
+	}
+
+	u, err = w.BuildURL("weather", "London", opt, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "date_format=&format=xml&key=REDACTED&q=London&tp=3"; u.RawQuery != want {
+		t.Errorf("redacted query %s, want %s", u.RawQuery, want)
+	}
+
+	if _, err := w.BuildURL("weather", "London", map[string]string{"tp": "5"}, false); err == nil {
+		t.Error("no error for an invalid option")
+	}
+}
+
+func TestGetTimeZone(t *testing.T) {
+	var h = &recorder{body: `<data><request><type>City</type><query>London, United Kingdom</query></request>` +
+		`<time_zone><localtime>2024-06-01 12:30</localtime><utcOffset>1.0</utcOffset><zone>Europe/London</zone></time_zone></data>`}
+	var w = testWWO(t, h)
+
+	tz, err := w.GetTimeZone("London", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tz.Zone.ZoneName != "Europe/London" || tz.Zone.Offset != 1 {
+		t.Errorf("Zone = %+v", tz.Zone)
+	}
+	if at, ok := tz.Zone.Time(); !ok || !at.Equal(time.Date(2024, 6, 1, 11, 30, 0, 0, time.UTC)) {
+		t.Errorf("Time() = %v, %v, want 12:30 an hour ahead of UTC", at, ok)
+	}
+	if dst, ok := tz.Zone.IsDST(); !dst || !ok {
+		t.Errorf("IsDST() = %v, %v, want true in June", dst, ok)
+	}
+
+	h.body = `<data><time_zone><localtime>2024-12-01 12:30</localtime><utcOffset>0.0</utcOffset><zone>Europe/London</zone></time_zone></data>`
+	if tz, err = w.GetTimeZone("London", nil); err != nil {
+		t.Fatal(err)
+	}
+	if dst, ok := tz.Zone.IsDST(); dst || !ok {
+		t.Errorf("IsDST() = %v, %v, want false in December", dst, ok)
+	}
+
+	// A zone without a name, as in forecasts.
+	if dst, ok := (&Zone{Offset: 1}).IsDST(); dst || ok {
+		t.Errorf("IsDST() without a zone name = %v, %v, want unknown", dst, ok)
+	}
+	if _, ok := (&Zone{Offset: 1}).Time(); ok {
+		t.Error("Time() without a local time is given")
+	}
+}
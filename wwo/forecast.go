@@ -0,0 +1,344 @@
+package wwo
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The forecast conditions for the period containing the time of day d,
+// which is the last at or before d, or the first if none are.
+// nil is returned if there are no conditions.
+func (w *ForecastWeather) ConditionAt(d time.Duration) *ForecastCondition {
+	var c *ForecastCondition
+
+	for i := range w.Condition {
+		if c == nil || time.Duration(w.Condition[i].Time) <= d {
+			c = &w.Condition[i]
+		}
+	}
+
+	return c
+}
+
+// Aggregates of the hourly conditions in a day's forecast.
+type HourlySummary struct {
+	Count             int     //        Number of hourly conditions summarised
+	MaxWindGust       uint    // km/hr  Highest wind gust
+	MaxWindGustMiles  uint    // mi/hr  Highest wind gust
+	TotalPrecip       float64 // mm     Total precipitation
+	TotalPrecipInches float64 // in     Total precipitation
+	AvgHumidity       float64 // %      Average humidity
+	WeatherCode       uint    //        Most frequent weather condition code, the earliest if tied
+	WeatherDesc       string  //        Description of the most frequent weather condition
+}
+
+// Summarise the hourly conditions of the forecast, however many there are.
+// The summary of no conditions is zero.
+func (w *ForecastWeather) Summary() HourlySummary {
+	var s HourlySummary
+	var counts = make(map[uint]int)
+	var humidity uint
+
+	for i := range w.Condition {
+		c := &w.Condition[i]
+
+		if c.WindGust > s.MaxWindGust {
+			s.MaxWindGust = c.WindGust
+		}
+		if c.WindGustMiles > s.MaxWindGustMiles {
+			s.MaxWindGustMiles = c.WindGustMiles
+		}
+		s.TotalPrecip += c.Precip
+		s.TotalPrecipInches += c.PrecipInches
+		humidity += c.Humidity
+
+		counts[c.WeatherCode]++
+		s.Count++
+	}
+
+	// Taking the first to be more frequent than those before it gives the earliest of any tied.
+	for i := range w.Condition {
+		c := &w.Condition[i]
+		if i == 0 || counts[c.WeatherCode] > counts[s.WeatherCode] {
+			s.WeatherCode = c.WeatherCode
+			s.WeatherDesc = c.WeatherDesc
+		}
+	}
+
+	if s.Count > 0 {
+		s.AvgHumidity = float64(humidity) / float64(s.Count)
+	}
+
+	return s
+}
+
+// The time of the current conditions' observation, which the API gives as a time of day in UTC,
+// taken to be the latest at that time of day that is not in the future,
+// and given in the nearest area's zone.
+//
+// If the response gave no zone for the area the time is given in the local time of the computer.
+// The zero time is returned if there is no observation time.
+func (l *Local) ObservationTime() time.Time {
+	return observationTime(l.Current.Time, areaLocation(l.Area), time.Now())
+}
+
+// The time of the observation in the nearest area's zone, as with Local.ObservationTime.
+func (c *Current) ObservationTime() time.Time {
+	return observationTime(c.Time, areaLocation(c.Area), time.Now())
+}
+
+// The location of the area's zone, or the local time of the computer if the response gave none.
+func areaLocation(a Area) *time.Location {
+	if a.Zone == nil {
+		return time.Local
+	}
+	return a.Zone.Location()
+}
+
+// The latest time at the time of day t in UTC that is not after now, in loc, zero if t is not a time.
+func observationTime(t Time12, loc *time.Location, now time.Time) time.Time {
+	if !t.Valid() {
+		return time.Time{}
+	}
+
+	y, m, d := now.UTC().Date()
+	ti := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Add(time.Duration(t))
+	if ti.After(now) {
+		ti = ti.AddDate(0, 0, -1)
+	}
+
+	return ti.In(loc)
+}
+
+// A forecast condition with the time it is for.
+type TimedCondition struct {
+	Time      time.Time          // Time of the condition in the nearest area's zone
+	Condition *ForecastCondition // The forecast condition
+}
+
+// All the hourly forecast conditions of every day, in order of time,
+// at whatever spacing they were requested, days without any being skipped.
+//
+// If the response gave no zone for the area the times are taken to be UTC.
+func (l *Local) HourlyTimeline() []TimedCondition {
+	var timeline []TimedCondition
+
+	for i := range l.Weather {
+		w := &l.Weather[i]
+		for j := range w.Condition {
+			c := &w.Condition[j]
+			timeline = append(timeline, TimedCondition{c.Time.On(w.Date, l.Area.Zone), c})
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Time.Before(timeline[j].Time)
+	})
+
+	return timeline
+}
+
+// The climate averages for the month m, matched by index or else by name,
+// nil if there are none, as when not requested with mca=yes.
+func (l *Local) ClimateForMonth(m time.Month) *ClimateAverage {
+	for i := range l.Climate {
+		if l.Climate[i].Index == uint(m) {
+			return &l.Climate[i]
+		}
+	}
+	for i := range l.Climate {
+		if strings.EqualFold(l.Climate[i].Name, m.String()) {
+			return &l.Climate[i]
+		}
+	}
+
+	return nil
+}
+
+// Total precipitation of the hourly conditions of every day of the forecast.
+func (l *Local) TotalPrecip() float64 {
+	var total float64
+	for i := range l.Weather {
+		total += l.Weather[i].Summary().TotalPrecip
+	}
+	return total
+}
+
+// Total precipitation of the hourly conditions of every day of the forecast, in inches.
+func (l *Local) TotalPrecipInches() float64 {
+	var total float64
+	for i := range l.Weather {
+		total += l.Weather[i].Summary().TotalPrecipInches
+	}
+	return total
+}
+
+// The number of days of the forecast whose hourly conditions total more than mm of precipitation.
+func (l *Local) PrecipDays(mm float64) int {
+	var n int
+	for i := range l.Weather {
+		if l.Weather[i].Summary().TotalPrecip > mm {
+			n++
+		}
+	}
+	return n
+}
+
+// A named chance of a condition.
+type Chance struct {
+	Name string // Name of the condition, e.g. "rain"
+	Pct  uint   // % Chance of the condition
+}
+
+// The chances as a list, in the order of their fields.
+func (f *ForecastChances) chances() []Chance {
+	return []Chance{
+		{"fog", f.ChanceFog},
+		{"frost", f.ChanceFrost},
+		{"overcast", f.ChanceOvercast},
+		{"rain", f.ChanceRain},
+		{"snow", f.ChanceSnow},
+		{"high temperature", f.ChanceHighTemp},
+		{"dry", f.ChanceDry},
+		{"sunshine", f.ChanceSunshine},
+		{"thunder", f.ChanceThunder},
+		{"windy", f.ChanceWindy},
+	}
+}
+
+// The name and chance of the most likely condition, if its chance is at least threshold,
+// otherwise an empty name and zero. Ties go to the earlier field.
+func (f *ForecastChances) Dominant(threshold uint) (string, uint) {
+	var best Chance
+
+	for _, c := range f.chances() {
+		if c.Pct >= threshold && c.Pct > best.Pct {
+			best = c
+		}
+	}
+
+	return best.Name, best.Pct
+}
+
+// The non-zero chances, most likely first, ties being in the order of their fields.
+func (f *ForecastChances) Ranked() []Chance {
+	var ranked []Chance
+
+	for _, c := range f.chances() {
+		if c.Pct > 0 {
+			ranked = append(ranked, c)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Pct > ranked[j].Pct
+	})
+
+	return ranked
+}
+
+// The number of hours each hourly condition covers, from the tp option sent, 3 if it was not.
+func (l *Local) TP() int {
+	return optionTP(l.Options)
+}
+
+// The number of hours each hourly condition covers, from the tp option sent, 3 if it was not.
+func (m *Marine) TP() int {
+	return optionTP(m.Options)
+}
+
+func optionTP(opt map[string]string) int {
+	if tp, err := strconv.Atoi(opt["tp"]); err == nil && tp > 0 {
+		return tp
+	}
+	return 3
+}
+
+// The current conditions with the rest of today's forecast.
+type TodaysWeather struct {
+	Current  CurrentCondition // Current weather conditions
+	Observed time.Time        // Time of the observation, as given by Local.ObservationTime
+	Hourly   []TimedCondition // Today's forecast conditions after the observation, in order of time
+}
+
+// The current conditions with the forecast conditions for the rest of the day of the observation,
+// in the nearest area's zone, or the local time of the computer if the response gave none.
+//
+// Without an observation time the conditions after the present are included.
+func (l *Local) Today() TodaysWeather {
+	return l.today(time.Now())
+}
+
+func (l *Local) today(now time.Time) TodaysWeather {
+	var loc = areaLocation(l.Area)
+	var t = TodaysWeather{Current: l.Current, Observed: observationTime(l.Current.Time, loc, now)}
+
+	after := t.Observed
+	if after.IsZero() {
+		after = now
+	}
+	y, m, d := after.In(loc).Date()
+
+	for i := range l.Weather {
+		w := &l.Weather[i]
+		wy, wm, wd := time.Time(w.Date).Date()
+		if wy != y || wm != m || wd != d {
+			continue
+		}
+		for j := range w.Condition {
+			c := &w.Condition[j]
+			at := time.Date(y, m, d, 0, 0, 0, 0, loc).Add(time.Duration(c.Time))
+			if at.After(after) {
+				t.Hourly = append(t.Hourly, TimedCondition{at, c})
+			}
+		}
+	}
+
+	sort.SliceStable(t.Hourly, func(i, j int) bool {
+		return t.Hourly[i].Time.Before(t.Hourly[j].Time)
+	})
+
+	return t
+}
+
+// A day of a Local Forecast in brief, without its hourly conditions.
+type DaySummary struct {
+	TempRange
+	Date        Date   //    Date of forecast
+	WeatherCode uint   //    Most frequent weather condition code of the day's conditions
+	WeatherDesc string //    Description of the most frequent weather condition
+	ChanceRain  uint   // %  Highest chance of rain of the day's conditions
+	Sunrise     Time12 //    Local time of sunrise
+	Sunset      Time12 //    Local time of sunset
+}
+
+// Summarise each day of the forecast, in the order given.
+// The weather condition is that of the day's Summary.
+func (l *Local) DailySummaries() []DaySummary {
+	var days = make([]DaySummary, 0, len(l.Weather))
+
+	for i := range l.Weather {
+		w := &l.Weather[i]
+		s := w.Summary()
+
+		var d = DaySummary{
+			TempRange:   w.TempRange,
+			Date:        w.Date,
+			WeatherCode: s.WeatherCode,
+			WeatherDesc: s.WeatherDesc,
+			Sunrise:     w.Astronomy.Sunrise,
+			Sunset:      w.Astronomy.Sunset,
+		}
+		for j := range w.Condition {
+			if c := w.Condition[j].ChanceRain; c > d.ChanceRain {
+				d.ChanceRain = c
+			}
+		}
+
+		days = append(days, d)
+	}
+
+	return days
+}
@@ -0,0 +1,276 @@
+package wwo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A day's forecast with conditions every three hours from midnight.
+func threeHourly() *ForecastWeather {
+	var w ForecastWeather
+	for h := 0; h < 24; h += 3 {
+		var c ForecastCondition
+		c.Time = TimeHMM(time.Duration(h) * time.Hour)
+		c.Temp = h
+		w.Condition = append(w.Condition, c)
+	}
+	return &w
+}
+
+func TestConditionAt(t *testing.T) {
+	var w = threeHourly()
+
+	for _, tt := range []struct {
+		at   time.Duration
+		want TimeHMM
+	}{
+		{14 * time.Hour, TimeHMM(12 * time.Hour)},
+		{12 * time.Hour, TimeHMM(12 * time.Hour)},
+		{0, 0},
+		{23*time.Hour + 59*time.Minute, TimeHMM(21 * time.Hour)},
+	} {
+		c := w.ConditionAt(tt.at)
+		if c == nil || c.Time != tt.want {
+			t.Errorf("ConditionAt(%v) = %v, want the conditions at %v", tt.at, c, tt.want)
+		}
+	}
+
+	// Before the first conditions the first is given.
+	w.Condition = w.Condition[1:]
+	if c := w.ConditionAt(time.Hour); c == nil || c.Time != TimeHMM(3*time.Hour) {
+		t.Errorf("ConditionAt(1h) before the first conditions = %v, want those at 03:00", c)
+	}
+
+	if c := (&ForecastWeather{}).ConditionAt(14 * time.Hour); c != nil {
+		t.Errorf("ConditionAt with no conditions = %v, want nil", c)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	var w ForecastWeather
+	for _, c := range []Condition{
+		{WindGust: 20, WindGustMiles: 12, Precip: 0.5, PrecipInches: 0.02, Humidity: 60, WeatherCode: 116, WeatherDesc: "Partly cloudy"},
+		{WindGust: 35, WindGustMiles: 22, Precip: 1.5, PrecipInches: 0.06, Humidity: 80, WeatherCode: 176, WeatherDesc: "Patchy rain possible"},
+		{WindGust: 30, WindGustMiles: 19, Precip: 2.0, PrecipInches: 0.08, Humidity: 90, WeatherCode: 176, WeatherDesc: "Patchy rain possible"},
+		{WindGust: 10, WindGustMiles: 6, Precip: 0, PrecipInches: 0, Humidity: 50, WeatherCode: 116, WeatherDesc: "Partly cloudy"},
+	} {
+		w.Condition = append(w.Condition, ForecastCondition{Condition: c})
+	}
+
+	s := w.Summary()
+	if s.Count != 4 || s.MaxWindGust != 35 || s.MaxWindGustMiles != 22 || s.TotalPrecip != 4 || s.AvgHumidity != 70 {
+		t.Errorf("Summary() = %+v", s)
+	}
+	if d := s.TotalPrecipInches - 0.16; d > 1e-9 || d < -1e-9 {
+		t.Errorf("TotalPrecipInches = %v, want 0.16", s.TotalPrecipInches)
+	}
+
+	// 116 and 176 are tied, so the earliest is taken.
+	if s.WeatherCode != 116 || s.WeatherDesc != "Partly cloudy" {
+		t.Errorf("most frequent condition %d %q, want the earliest of those tied, 116", s.WeatherCode, s.WeatherDesc)
+	}
+
+	if s := (&ForecastWeather{}).Summary(); s != (HourlySummary{}) {
+		t.Errorf("Summary() of no conditions = %+v, want zero", s)
+	}
+}
+
+func TestObservationTime(t *testing.T) {
+	var loc = (&Zone{Offset: 5.5}).Location()
+	var observed = Time12(6*time.Hour + 15*time.Minute)
+
+	// 12:00 on 1 June in the zone, the observation being given in UTC.
+	now := time.Date(2024, 6, 1, 6, 30, 0, 0, time.UTC)
+	got := observationTime(observed, loc, now)
+	if want := time.Date(2024, 6, 1, 6, 15, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("observation at 06:15 UTC seen at 06:30 UTC = %v, want %v", got, want)
+	}
+	if _, offset := got.Zone(); offset != 5*3600+30*60 {
+		t.Errorf("observation time %v not in the area's zone", got)
+	}
+
+	// 00:30 on 2 June in UTC, so the observation was the previous day, though it is 2 June in the zone.
+	now = time.Date(2024, 6, 2, 0, 30, 0, 0, time.UTC)
+	observed = Time12(23*time.Hour + 45*time.Minute)
+	if got, want := observationTime(observed, loc, now), time.Date(2024, 6, 1, 23, 45, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("observation at 23:45 UTC seen at 00:30 UTC = %v, want %v", got, want)
+	}
+
+	if got := observationTime(Time12(-1), loc, now); !got.IsZero() {
+		t.Errorf("observation time of no event = %v, want zero", got)
+	}
+
+	var l = Local{Area: Area{Zone: &Zone{Offset: -4}}, Current: CurrentCondition{Time: observed}}
+	got = l.ObservationTime()
+	if h, m, _ := got.UTC().Clock(); h != 23 || m != 45 {
+		t.Errorf("ObservationTime() = %v, want 23:45 UTC", got)
+	}
+	if _, offset := got.Zone(); offset != -4*3600 {
+		t.Errorf("ObservationTime() = %v, not in the area's zone", got)
+	}
+	if got.After(time.Now()) {
+		t.Errorf("ObservationTime() = %v, in the future", got)
+	}
+}
+
+func TestHourlyTimeline(t *testing.T) {
+	var l Local
+	l.Area.Zone = &Zone{Offset: 1}
+
+	for _, date := range []time.Time{time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)} {
+		w := threeHourly()
+		w.Date = Date(date)
+		l.Weather = append(l.Weather, *w)
+	}
+
+	timeline := l.HourlyTimeline()
+	if len(timeline) != 16 {
+		t.Fatalf("%d conditions in the timeline, want 16", len(timeline))
+	}
+
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, l.Area.Zone.Location())
+	for i, tc := range timeline {
+		if want := start.Add(time.Duration(i) * 3 * time.Hour); !tc.Time.Equal(want) {
+			t.Errorf("timeline[%d] at %v, want %v", i, tc.Time, want)
+		}
+		if tc.Condition.Temp != i%8*3 {
+			t.Errorf("timeline[%d] is the conditions at %dh", i, tc.Condition.Temp)
+		}
+	}
+}
+
+func TestClimateForMonth(t *testing.T) {
+	var l Local
+	if err := xml.Unmarshal([]byte(climateXML()), &l); err != nil {
+		t.Fatal(err)
+	}
+
+	for m := time.January; m <= time.December; m++ {
+		c := l.ClimateForMonth(m)
+		if c == nil || c.Index != uint(m) || c.Name != m.String() || c.Temp != float64(m) {
+			t.Errorf("ClimateForMonth(%v) = %+v", m, c)
+		}
+	}
+
+	if c := new(Local).ClimateForMonth(time.June); c != nil {
+		t.Errorf("ClimateForMonth(June) = %+v without climate averages, want nil", c)
+	}
+}
+
+func TestTotalPrecip(t *testing.T) {
+	var l Local
+	for _, day := range [][]float64{{0, 0.5, 1.5, 2}, {0.25, 0.25}, {0}} {
+		var w ForecastWeather
+		for _, mm := range day {
+			var c ForecastCondition
+			c.Precip = mm
+			c.PrecipInches = mm / 16
+			w.Condition = append(w.Condition, c)
+		}
+		l.Weather = append(l.Weather, w)
+	}
+
+	if got := l.TotalPrecip(); got != 4.5 {
+		t.Errorf("TotalPrecip() = %v, want 4.5", got)
+	}
+	if got := l.TotalPrecipInches(); got != 4.5/16 {
+		t.Errorf("TotalPrecipInches() = %v, want %v", got, 4.5/16)
+	}
+	for _, tt := range []struct {
+		mm   float64
+		want int
+	}{
+		{-1, 3},
+		{0, 2},
+		{0.5, 1},
+		{4, 0},
+	} {
+		if got := l.PrecipDays(tt.mm); got != tt.want {
+			t.Errorf("PrecipDays(%v) = %d, want %d", tt.mm, got, tt.want)
+		}
+	}
+}
+
+func TestDominant(t *testing.T) {
+	var f = ForecastChances{ChanceRain: 80, ChanceThunder: 30}
+
+	if name, pct := f.Dominant(50); name != "rain" || pct != 80 {
+		t.Errorf("Dominant(50) = %q, %d, want rain, 80", name, pct)
+	}
+	if name, pct := f.Dominant(90); name != "" || pct != 0 {
+		t.Errorf("Dominant(90) = %q, %d, want none", name, pct)
+	}
+
+	want := []Chance{{"rain", 80}, {"thunder", 30}}
+	if got := f.Ranked(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Ranked() = %v, want %v", got, want)
+	}
+}
+
+func TestToday(t *testing.T) {
+	var day, next = threeHourly(), threeHourly()
+	day.Date = Date(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	next.Date = Date(time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC))
+
+	var l = Local{Area: Area{Zone: &Zone{Offset: 1}}, Weather: []ForecastWeather{*day, *next}}
+	l.Current.Time = Time12(11*time.Hour + 15*time.Minute) // In UTC, 12:15 in the zone
+
+	loc := time.FixedZone("", 3600)
+	today := l.today(time.Date(2024, 6, 1, 12, 40, 0, 0, loc))
+
+	if want := time.Date(2024, 6, 1, 12, 15, 0, 0, loc); !today.Observed.Equal(want) {
+		t.Errorf("Observed = %v, want %v", today.Observed, want)
+	}
+	var hours []int
+	for _, c := range today.Hourly {
+		if c.Time.Hour() != c.Condition.Temp || c.Time.Day() != 1 {
+			t.Errorf("condition for %v at %v", c.Condition.Temp, c.Time)
+		}
+		hours = append(hours, c.Time.In(loc).Hour())
+	}
+	if fmt.Sprint(hours) != "[15 18 21]" {
+		t.Errorf("conditions at %v, want the afternoon's, [15 18 21]", hours)
+	}
+}
+
+func TestDailySummaries(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("<data>")
+	for d := 1; d <= 3; d++ {
+		fmt.Fprintf(&b, "<weather><date>2024-06-%02d</date><maxtempC>%d</maxtempC><mintempC>%d</mintempC>", d, 20+d, 10-d)
+		fmt.Fprintf(&b, "<astronomy><sunrise>04:4%d AM</sunrise><sunset>09:1%d PM</sunset></astronomy>", d, d)
+		fmt.Fprintf(&b, "<hourly><time>0</time><weatherCode>113</weatherCode><weatherDesc>Clear</weatherDesc><chanceofrain>%d</chanceofrain></hourly>", 10*d)
+		fmt.Fprintf(&b, "<hourly><time>1200</time><weatherCode>296</weatherCode><weatherDesc>Light rain</weatherDesc><chanceofrain>%d</chanceofrain></hourly>", 20*d)
+		fmt.Fprintf(&b, "<hourly><time>1800</time><weatherCode>296</weatherCode><weatherDesc>Light rain</weatherDesc><chanceofrain>5</chanceofrain></hourly>")
+		b.WriteString("</weather>")
+	}
+	b.WriteString("</data>")
+
+	var l Local
+	if err := xml.Unmarshal([]byte(b.String()), &l); err != nil {
+		t.Fatal(err)
+	}
+
+	days := l.DailySummaries()
+	if len(days) != 3 {
+		t.Fatalf("%d summaries for 3 days", len(days))
+	}
+	for i, s := range days {
+		d := i + 1
+		if got := time.Time(s.Date).Format("2006-01-02"); got != fmt.Sprintf("2024-06-%02d", d) {
+			t.Errorf("day %d: Date %s", d, got)
+		}
+		if s.MaxTemp != 20+d || s.MinTemp != 10-d {
+			t.Errorf("day %d: high %d, low %d, want %d, %d", d, s.MaxTemp, s.MinTemp, 20+d, 10-d)
+		}
+		if s.WeatherCode != 296 || s.WeatherDesc != "Light rain" || s.ChanceRain != uint(20*d) {
+			t.Errorf("day %d: weather %d %q, chance of rain %d, want 296 \"Light rain\", %d", d, s.WeatherCode, s.WeatherDesc, s.ChanceRain, 20*d)
+		}
+		if s.Sunrise.String() != fmt.Sprintf("04:4%d", d) || s.Sunset.String() != fmt.Sprintf("21:1%d", d) {
+			t.Errorf("day %d: sunrise %v, sunset %v", d, s.Sunrise, s.Sunset)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package wwo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Download the weather icon for the conditions, returning the image and its content type.
+//
+// client is used for the request, http.DefaultClient if nil,
+// so passing the HTTPClient of a WWO keeps its transport settings.
+func (c *Condition) FetchIcon(ctx context.Context, client *http.Client) ([]byte, string, error) {
+	if c.WeatherIconUrl == "" {
+		return nil, "", errors.New("wwo: no weather icon")
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.WeatherIconUrl, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer resp.Body.Close()
+	image, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, "", &HTTPError{resp.StatusCode, resp.Status, image}
+	}
+
+	return image, resp.Header.Get("Content-Type"), nil
+}
+
+// Day icons of the WorldWeatherOnline symbol set with their night variants, by file name without extension.
+// Icons not listed are used by day and night alike.
+var nightIcons = map[string]string{
+	"wsymbol_0001_sunny":                  "wsymbol_0008_clear_sky_night",
+	"wsymbol_0002_sunny_intervals":        "wsymbol_0041_partly_cloudy_night",
+	"wsymbol_0009_light_rain_showers":     "wsymbol_0025_light_rain_showers_night",
+	"wsymbol_0010_heavy_rain_showers":     "wsymbol_0026_heavy_rain_showers_night",
+	"wsymbol_0011_light_snow_showers":     "wsymbol_0027_light_snow_showers_night",
+	"wsymbol_0012_heavy_snow_showers":     "wsymbol_0028_heavy_snow_showers_night",
+	"wsymbol_0013_sleet_showers":          "wsymbol_0029_sleet_showers_night",
+	"wsymbol_0016_thundery_showers":       "wsymbol_0032_thundery_showers_night",
+	"wsymbol_0017_cloudy_with_light_rain": "wsymbol_0033_cloudy_with_light_rain_night",
+	"wsymbol_0018_cloudy_with_heavy_rain": "wsymbol_0034_cloudy_with_heavy_rain_night",
+	"wsymbol_0019_cloudy_with_light_snow": "wsymbol_0035_cloudy_with_light_snow_night",
+	"wsymbol_0020_cloudy_with_heavy_snow": "wsymbol_0036_cloudy_with_heavy_snow_night",
+	"wsymbol_0021_cloudy_with_sleet":      "wsymbol_0037_cloudy_with_sleet_night",
+}
+
+// The URL of the weather icon for the conditions in its night variant if night, otherwise its day variant.
+// The URL is returned unchanged if the icon is not one with day and night variants.
+func (c *Condition) IconURL(night bool) string {
+	u := c.WeatherIconUrl
+	slash := strings.LastIndex(u, "/") + 1
+	dot := strings.LastIndex(u, ".")
+	if dot < slash {
+		dot = len(u)
+	}
+	name := u[slash:dot]
+
+	for day, n := range nightIcons {
+		if night && name == day {
+			return u[:slash] + n + u[dot:]
+		}
+		if !night && name == n {
+			return u[:slash] + day + u[dot:]
+		}
+	}
+
+	return u
+}
@@ -0,0 +1,67 @@
+package wwo
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchIcon(t *testing.T) {
+	var png = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wsymbol_0001_sunny.png" {
+			http.NotFound(rw, r)
+			return
+		}
+		rw.Header().Set("Content-Type", "image/png")
+		rw.Write(png)
+	}))
+	defer s.Close()
+
+	var c = Condition{WeatherIconUrl: s.URL + "/wsymbol_0001_sunny.png"}
+	image, contentType, err := c.FetchIcon(context.Background(), s.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(image, png) || contentType != "image/png" {
+		t.Errorf("FetchIcon() = %q, %q, want the PNG served", image, contentType)
+	}
+
+	c.WeatherIconUrl = s.URL + "/missing.png"
+	if _, _, err := c.FetchIcon(context.Background(), nil); err == nil {
+		t.Error("no error fetching a missing icon")
+	}
+
+	if _, _, err := (&Condition{}).FetchIcon(context.Background(), nil); err == nil {
+		t.Error("no error fetching without an icon URL")
+	}
+}
+
+func TestIconURL(t *testing.T) {
+	const (
+		day   = "https://cdn.worldweatheronline.com/images/wsymbols01_png_64/wsymbol_0001_sunny.png"
+		night = "https://cdn.worldweatheronline.com/images/wsymbols01_png_64/wsymbol_0008_clear_sky_night.png"
+		both  = "https://cdn.worldweatheronline.com/images/wsymbols01_png_64/wsymbol_0004_black_low_cloud.png"
+	)
+
+	for _, tt := range []struct {
+		url   string
+		night bool
+		want  string
+	}{
+		{day, true, night},
+		{day, false, day},
+		{night, false, day},
+		{night, true, night},
+		{both, true, both},
+		{both, false, both},
+	} {
+		var c = Condition{WeatherIconUrl: tt.url}
+		if got := c.IconURL(tt.night); got != tt.want {
+			t.Errorf("IconURL(%v) of %s = %s, want %s", tt.night, tt.url, got, tt.want)
+		}
+	}
+}
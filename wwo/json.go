@@ -0,0 +1,149 @@
+package wwo
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// Formats in which responses can be requested from the API.
+type Format int
+
+const (
+	FormatXML  Format = iota // XML, the default
+	FormatJSON               // JSON
+)
+
+func (f Format) String() string {
+	if f == FormatJSON {
+		return "json"
+	}
+	return "xml"
+}
+
+// Whether text is clearly not in format f, such as being an HTML page.
+// The content type is not trusted for this, so only the start of text is looked at.
+func (f Format) mismatch(text []byte) bool {
+	text = bytes.TrimSpace(bytes.TrimPrefix(text, []byte("\xef\xbb\xbf")))
+	if len(text) == 0 {
+		return false
+	}
+
+	start := text
+	if len(start) > 16 {
+		start = start[:16]
+	}
+	start = bytes.ToLower(start)
+	if bytes.HasPrefix(start, []byte("<!doctype html")) || bytes.HasPrefix(start, []byte("<html")) {
+		return true
+	}
+
+	if f == FormatJSON {
+		return text[0] != '{' && text[0] != '['
+	}
+	return text[0] != '<'
+}
+
+// The format text is in, going by its first character, XML unless it is clearly JSON.
+func sniffFormat(text []byte) Format {
+	text = bytes.TrimSpace(bytes.TrimPrefix(text, []byte("\xef\xbb\xbf")))
+	if len(text) > 0 && (text[0] == '{' || text[0] == '[') {
+		return FormatJSON
+	}
+	return FormatXML
+}
+
+// Translate a JSON response into the equivalent XML document,
+// so both formats are decoded using the same structure tags.
+//
+// The JSON responses wrap every element in an array,
+// and text values in an object holding only a "value",
+// both of which are undone here.
+func jsonToXML(text []byte) ([]byte, error) {
+	var v interface{}
+
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	root, ok := v.(map[string]interface{})
+	if !ok || len(root) != 1 {
+		return nil, errors.New("wwo: unexpected JSON response")
+	}
+
+	var b bytes.Buffer
+	e := xml.NewEncoder(&b)
+	for name, v := range root {
+		if err := encodeJSONValue(e, name, v); err != nil {
+			return nil, err
+		}
+	}
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+func encodeJSONValue(e *xml.Encoder, name string, v interface{}) error {
+	if a, ok := v.([]interface{}); ok {
+		for _, v := range a {
+			if err := encodeJSONValue(e, name, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if text, ok := v["value"]; ok && len(v) == 1 {
+			if err := encodeJSONText(e, text); err != nil {
+				return err
+			}
+			break
+		}
+
+		var names []string
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if err := encodeJSONValue(e, name, v[name]); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := encodeJSONText(e, v); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func encodeJSONText(e *xml.Encoder, v interface{}) error {
+	switch v := v.(type) {
+	case string:
+		return e.EncodeToken(xml.CharData(v))
+	case json.Number:
+		return e.EncodeToken(xml.CharData(v.String()))
+	case bool:
+		return e.EncodeToken(xml.CharData(strconv.FormatBool(v)))
+	case nil:
+		return nil
+	}
+	return errors.New("wwo: unexpected JSON value")
+}
@@ -0,0 +1,50 @@
+package wwo
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+// A local forecast in the API's JSON format, cut down from a real response.
+const localJSON = `{"data":{"request":[{"type":"City","query":"London, United Kingdom"}],
+"current_condition":[{"observation_time":"12:15 PM","temp_C":"12","temp_F":"54",
+	"weatherCode":"116","weatherIconUrl":[{"value":"https://cdn.worldweatheronline.com/images/wsymbols01_png_64/wsymbol_0002_sunny_intervals.png"}],
+	"weatherDesc":[{"value":"Partly cloudy"}],"windspeedMiles":"9","windspeedKmph":"15","winddirDegree":"320","winddir16Point":"NW",
+	"precipMM":"0.0","precipInches":"0.0","humidity":"80","visibility":"10","visibilityMiles":"6","pressure":"1013","pressureInches":"30",
+	"cloudcover":"50","FeelsLikeC":"11","FeelsLikeF":"51","uvIndex":"3"}],
+"weather":[{"date":"2024-06-01","astronomy":[{"sunrise":"04:45 AM","sunset":"09:10 PM","moonrise":"02:13 AM","moonset":"04:39 PM",
+	"moon_phase":"Waning Crescent","moon_illumination":"27"}],
+	"maxtempC":"20","maxtempF":"68","mintempC":"10","mintempF":"50","totalSnow_cm":"0.0","sunHour":"11.6","uvIndex":"4",
+	"hourly":[{"time":"0","tempC":"10","tempF":"50","weatherCode":"113","weatherDesc":[{"value":"Clear"}],"chanceofrain":"0"},
+		{"time":"1200","tempC":"19","tempF":"66","weatherCode":"176","weatherDesc":[{"value":"Patchy rain possible"}],"chanceofrain":"70"}]}]}}`
+
+func TestJSONToXML(t *testing.T) {
+	body, err := jsonToXML([]byte(localJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var l Local
+	if err := xml.Unmarshal(body, &l); err != nil {
+		t.Fatalf("decoding %s: %v", body, err)
+	}
+
+	if l.Request.Query != "London, United Kingdom" {
+		t.Errorf("Request.Query = %q", l.Request.Query)
+	}
+	if c := l.Current; c.Temp != 12 || c.WeatherDesc != "Partly cloudy" || c.WindDirCompass != "NW" || c.Time != Time12(12*time.Hour+15*time.Minute) {
+		t.Errorf("Current = %+v", c)
+	}
+	if len(l.Weather) != 1 {
+		t.Fatalf("%d days of weather, want 1", len(l.Weather))
+	}
+
+	w := l.Weather[0]
+	if w.Date.String() != "2024-06-01" || w.MaxTemp != 20 || w.Astronomy.Sunset != Time12(21*time.Hour+10*time.Minute) {
+		t.Errorf("Weather = %+v", w.Weather)
+	}
+	if len(w.Condition) != 2 || w.Condition[1].Time != TimeHMM(12*time.Hour) || w.Condition[1].ChanceRain != 70 {
+		t.Errorf("hourly conditions %+v", w.Condition)
+	}
+}
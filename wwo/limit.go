@@ -0,0 +1,57 @@
+package wwo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limits the rate at which requests are made, waiting until the next is allowed.
+//
+// A *rate.Limiter from golang.org/x/time/rate satisfies this,
+// as does the simpler one returned by NewLimiter.
+// Like a Cache, it must be safe for concurrent use if its WWO is shared between goroutines.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// A Limiter allowing n requests per second, evenly spaced, or any number if n <= 0.
+// It may be shared between goroutines, and WWO structures using the same key.
+func NewLimiter(n float64) Limiter {
+	var l = new(limiter)
+	if n > 0 {
+		l.interval = time.Duration(float64(time.Second) / n)
+	}
+	return l
+}
+
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration // Minimum time between requests
+	next     time.Time     // Time at which the next request may be made
+}
+
+func (l *limiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	if !at.After(now) {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(at.Sub(now))
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,42 @@
+package wwo
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	var w, rt = countingWWO()
+	w.Limiter = NewLimiter(20)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := w.GetLocal("London", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	// The first request is immediate, the rest at least 50ms apart.
+	// The upper bound is generous, as a loaded machine may be slow to wake the waiting requests.
+	if elapsed < 200*time.Millisecond || elapsed > 10*time.Second {
+		t.Errorf("5 requests at 20 per second took %v, want about 200ms", elapsed)
+	}
+	if rt.count() != 5 {
+		t.Errorf("%d requests made, want 5", rt.count())
+	}
+}
+
+func TestLimiterContext(t *testing.T) {
+	var l = NewLimiter(1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Wait beyond the deadline: error %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,51 @@
+package wwo
+
+import (
+	"time"
+)
+
+// Set the absolute times of the forecast, from the local times of day and dates given, in the zone z,
+// which is UTC if nil, and is usually l.Area.Zone.
+//
+// This modifies l in place, setting the At fields of the hourly conditions,
+// the *At fields of each day's Astronomy, and the At field of the current conditions,
+// which is the observation time found as by Local.ObservationTime, given in z.
+func (l *Local) LocalizeTimes(z *Zone) {
+	for i := range l.Weather {
+		w := &l.Weather[i]
+		w.Astronomy.localize(w.Date, z)
+		for j := range w.Condition {
+			c := &w.Condition[j]
+			c.At = c.Time.On(w.Date, z)
+		}
+	}
+
+	l.Current.At = observationTime(l.Current.Time, z.Location(), time.Now())
+}
+
+// Set the absolute times of the forecast, from the local times of day and dates given, in the zone z,
+// which is UTC if nil, and is usually m.Area.Zone.
+//
+// This modifies m in place, setting the At fields of the hourly conditions and tides,
+// and the *At fields of each day's Astronomy.
+func (m *Marine) LocalizeTimes(z *Zone) {
+	for i := range m.Weather {
+		w := &m.Weather[i]
+		w.Astronomy.localize(w.Date, z)
+		for j := range w.Condition {
+			c := &w.Condition[j]
+			c.At = c.Time.On(w.Date, z)
+		}
+		for j := range w.Tide {
+			t := &w.Tide[j]
+			t.At, _ = t.Time.On(w.Date, z)
+		}
+	}
+}
+
+func (a *Astronomy) localize(d Date, z *Zone) {
+	a.MoonriseAt, _ = a.Moonrise.On(d, z)
+	a.MoonsetAt, _ = a.Moonset.On(d, z)
+	a.SunriseAt, _ = a.Sunrise.On(d, z)
+	a.SunsetAt, _ = a.Sunset.On(d, z)
+}
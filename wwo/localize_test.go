@@ -0,0 +1,65 @@
+package wwo
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestLocalizeTimes(t *testing.T) {
+	var z = &Zone{Offset: 1}
+	var loc = time.FixedZone("", 3600)
+
+	var l Local
+	if err := xml.Unmarshal([]byte(localXML), &l); err != nil {
+		t.Fatal(err)
+	}
+	l.LocalizeTimes(z)
+
+	a := l.Weather[0].Astronomy
+	for _, tt := range []struct {
+		name      string
+		got, want time.Time
+	}{
+		{"SunriseAt", a.SunriseAt, time.Date(2024, 6, 1, 4, 45, 0, 0, loc)},
+		{"SunsetAt", a.SunsetAt, time.Date(2024, 6, 1, 21, 10, 0, 0, loc)},
+		{"MoonsetAt", a.MoonsetAt, time.Date(2024, 6, 1, 16, 39, 0, 0, loc)},
+		{"MoonriseAt", a.MoonriseAt, time.Time{}},
+		{"Condition[1].At", l.Weather[0].Condition[1].At, time.Date(2024, 6, 1, 12, 0, 0, 0, loc)},
+	} {
+		if !tt.got.Equal(tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// The observation time is given in UTC, 12:15 PM, and placed in the zone.
+	at := l.Current.At
+	if _, offset := at.Zone(); offset != 3600 || at.UTC().Hour() != 12 || at.UTC().Minute() != 15 || at.After(time.Now()) {
+		t.Errorf("Current.At = %v, want 12:15 UTC in the zone, not in the future", at)
+	}
+
+	// The tides of a marine forecast in the same zone.
+	var m = marine(t)
+	m.Weather[0].Astronomy = a
+	m.LocalizeTimes(m.Area.Zone)
+
+	if got := m.Weather[0].Astronomy.SunriseAt; !got.Equal(a.SunriseAt) {
+		t.Errorf("marine SunriseAt = %v, want %v", got, a.SunriseAt)
+	}
+	for i, want := range []time.Time{
+		time.Date(2024, 6, 1, 3, 10, 0, 0, loc),
+		time.Date(2024, 6, 1, 9, 25, 0, 0, loc),
+		time.Date(2024, 6, 1, 15, 40, 0, 0, loc),
+		time.Date(2024, 6, 1, 21, 55, 0, 0, loc),
+	} {
+		if got := m.Weather[0].Tide[i].At; !got.Equal(want) {
+			t.Errorf("Tide[%d].At = %v, want %v", i, got, want)
+		}
+	}
+	if got, want := m.Weather[1].Tide[0].At, time.Date(2024, 6, 2, 4, 5, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("second day's Tide[0].At = %v, want %v", got, want)
+	}
+	if got, want := m.Weather[0].Condition[0].At, time.Date(2024, 6, 1, 0, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("Condition[0].At = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+package wwo
+
+import (
+	"net/url"
+)
+
+// Receives a line describing each response, for debugging.
+//
+// A *log.Logger from the standard library satisfies this,
+// and may be called by many goroutines at once.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// The URL u with the value of its key parameter, the API key, replaced, so it may be logged.
+func redact(u string) string {
+	p, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+
+	values := p.Query()
+	if values.Get("key") != "" {
+		values.Set("key", "REDACTED")
+		p.RawQuery = values.Encode()
+	}
+
+	return p.String()
+}
@@ -0,0 +1,45 @@
+package wwo
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// A Logger keeping the lines logged.
+type capture struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (c *capture) Printf(format string, v ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lines = append(c.lines, fmt.Sprintf(format, v...))
+}
+
+func TestLogger(t *testing.T) {
+	var l = new(capture)
+	var w = testWWO(t, respond(currentXML))
+	w.Key = "secret"
+	w.Logger = l
+
+	if _, err := w.GetLocal("London", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(l.lines) != 1 {
+		t.Fatalf("%d lines logged, want 1: %q", len(l.lines), l.lines)
+	}
+	line := l.lines[0]
+	if strings.Contains(line, "secret") {
+		t.Errorf("API key logged in %q", line)
+	}
+	for _, s := range []string{"/weather.ashx?", "q=London", "key=REDACTED", "200 OK", fmt.Sprintf("%d bytes", len(currentXML))} {
+		if !strings.Contains(line, s) {
+			t.Errorf("%q logged, want it to include %q", line, s)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package wwo
+
+import (
+	"strings"
+	"time"
+)
+
+// The swell of marine conditions, with its height in each of the units given.
+type Swell struct {
+	HeightM    float64 // m     Swell wave height
+	HeightFt   float64 // ft    Swell wave height
+	DirDeg     uint    // °EoN  Swell direction
+	DirCompass string  //       Swell compass direction
+	PeriodSecs float64 // sec   Swell period
+}
+
+// The swell of the conditions, from its separate fields.
+func (c *MarineCondition) Swell() Swell {
+	return Swell{
+		HeightM:    c.SwellHeight,
+		HeightFt:   c.SwellHeight_ft,
+		DirDeg:     c.SwellDir,
+		DirCompass: c.SwellDirCompass,
+		PeriodSecs: c.SwellPeriod,
+	}
+}
+
+// The time and height of the day's next high tide after the time after, taking the tide times to be in the zone z,
+// or UTC if nil. ok is false if there is none left in the day.
+func (w *MarineWeather) NextHighTide(after time.Time, z *Zone) (t time.Time, height float64, ok bool) {
+	return nextTide(w.Tide, w.Date, z, "High", after)
+}
+
+// The time and height of the day's next low tide after the time after, taking the tide times to be in the zone z,
+// or UTC if nil. ok is false if there is none left in the day.
+func (w *MarineWeather) NextLowTide(after time.Time, z *Zone) (t time.Time, height float64, ok bool) {
+	return nextTide(w.Tide, w.Date, z, "Low", after)
+}
+
+// The time and height of the next high tide after the time after in any day of the forecast,
+// so moving on to the following days when none remain in a day.
+// ok is false if there is none left in the forecast.
+func (m *Marine) NextHighTide(after time.Time) (t time.Time, height float64, ok bool) {
+	return m.nextTide("High", after)
+}
+
+// The time and height of the next low tide after the time after in any day of the forecast,
+// so moving on to the following days when none remain in a day.
+// ok is false if there is none left in the forecast.
+func (m *Marine) NextLowTide(after time.Time) (t time.Time, height float64, ok bool) {
+	return m.nextTide("Low", after)
+}
+
+func (m *Marine) nextTide(typ string, after time.Time) (t time.Time, height float64, ok bool) {
+	for i := range m.Weather {
+		w := &m.Weather[i]
+		if ti, h, found := nextTide(w.Tide, w.Date, m.Area.Zone, typ, after); found && (!ok || ti.Before(t)) {
+			t, height, ok = ti, h, true
+		}
+	}
+
+	return t, height, ok
+}
+
+// The earliest of the tides of type typ on the day d after the time after.
+func nextTide(tides []Tide, d Date, z *Zone, typ string, after time.Time) (t time.Time, height float64, ok bool) {
+	for i := range tides {
+		if !strings.EqualFold(tides[i].Type, typ) {
+			continue
+		}
+		ti, valid := tides[i].Time.On(d, z)
+		if valid && ti.After(after) && (!ok || ti.Before(t)) {
+			t, height, ok = ti, tides[i].Height, true
+		}
+	}
+
+	return t, height, ok
+}
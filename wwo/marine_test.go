@@ -0,0 +1,84 @@
+package wwo
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+// Two days of a marine forecast an hour ahead of UTC, with four tides on the first day
+// and one on the second.
+const marineXML = `<data>
+<nearest_area><areaName>Brighton</areaName><timezone><utcOffset>1.0</utcOffset></timezone></nearest_area>
+<weather>
+	<date>2024-06-01</date>
+	<tides>
+		<tide_data><tideTime>3:10 AM</tideTime><tideHeight_mt>4.1</tideHeight_mt><tide_type>HIGH</tide_type></tide_data>
+		<tide_data><tideTime>9:25 AM</tideTime><tideHeight_mt>0.8</tideHeight_mt><tide_type>LOW</tide_type></tide_data>
+		<tide_data><tideTime>3:40 PM</tideTime><tideHeight_mt>4.3</tideHeight_mt><tide_type>HIGH</tide_type></tide_data>
+		<tide_data><tideTime>9:55 PM</tideTime><tideHeight_mt>0.6</tideHeight_mt><tide_type>LOW</tide_type></tide_data>
+	</tides>
+	<hourly>
+		<time>0</time>
+		<swellHeight_m>1.2</swellHeight_m>
+		<swellHeight_ft>3.9</swellHeight_ft>
+		<swellDir>200</swellDir>
+		<swellDir16Point>SSW</swellDir16Point>
+		<swellPeriod_secs>8.5</swellPeriod_secs>
+	</hourly>
+</weather>
+<weather>
+	<date>2024-06-02</date>
+	<tides>
+		<tide_data><tideTime>4:05 AM</tideTime><tideHeight_mt>4.2</tideHeight_mt><tide_type>HIGH</tide_type></tide_data>
+	</tides>
+</weather>
+</data>`
+
+func marine(t *testing.T) *Marine {
+	t.Helper()
+
+	var m Marine
+	if err := xml.Unmarshal([]byte(marineXML), &m); err != nil {
+		t.Fatal(err)
+	}
+	return &m
+}
+
+func TestSwell(t *testing.T) {
+	var m = marine(t)
+
+	c := m.Weather[0].Condition[0]
+	if c.SwellHeight != 1.2 || c.SwellHeight_ft != 3.9 {
+		t.Errorf("swell height %v m, %v ft, want 1.2 m, 3.9 ft", c.SwellHeight, c.SwellHeight_ft)
+	}
+
+	want := Swell{HeightM: 1.2, HeightFt: 3.9, DirDeg: 200, DirCompass: "SSW", PeriodSecs: 8.5}
+	if got := c.Swell(); got != want {
+		t.Errorf("Swell() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNextTide(t *testing.T) {
+	var m = marine(t)
+	var z = time.FixedZone("", 3600)
+	var d = &m.Weather[0]
+
+	at, h, ok := d.NextHighTide(time.Date(2024, 6, 1, 10, 0, 0, 0, z), m.Area.Zone)
+	if want := time.Date(2024, 6, 1, 15, 40, 0, 0, z); !ok || !at.Equal(want) || h != 4.3 {
+		t.Errorf("NextHighTide after 10:00 = %v, %v, %v, want %v, 4.3", at, h, ok, want)
+	}
+	at, h, ok = d.NextLowTide(time.Date(2024, 6, 1, 10, 0, 0, 0, z), m.Area.Zone)
+	if want := time.Date(2024, 6, 1, 21, 55, 0, 0, z); !ok || !at.Equal(want) || h != 0.6 {
+		t.Errorf("NextLowTide after 10:00 = %v, %v, %v, want %v, 0.6", at, h, ok, want)
+	}
+
+	after := time.Date(2024, 6, 1, 16, 0, 0, 0, z)
+	if at, _, ok := d.NextHighTide(after, m.Area.Zone); ok {
+		t.Errorf("NextHighTide after 16:00 = %v, want none left in the day", at)
+	}
+	at, h, ok = m.NextHighTide(after)
+	if want := time.Date(2024, 6, 2, 4, 5, 0, 0, z); !ok || !at.Equal(want) || h != 4.2 {
+		t.Errorf("Marine.NextHighTide after 16:00 = %v, %v, %v, want %v, 4.2", at, h, ok, want)
+	}
+}
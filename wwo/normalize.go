@@ -0,0 +1,130 @@
+package wwo
+
+import (
+	"math"
+	"reflect"
+)
+
+// A field in metric units with its imperial counterpart, by name,
+// and the conversions between them.
+type unitPair struct {
+	metric, imperial     string
+	toMetric, toImperial func(float64) float64
+}
+
+// A pair of temperature fields, in °C and °F.
+func tempPair(metric, imperial string) unitPair {
+	return unitPair{
+		metric, imperial,
+		func(f float64) float64 { return (f - 32) * 5 / 9 },
+		func(c float64) float64 { return c*9/5 + 32 },
+	}
+}
+
+// A pair of fields where one imperial unit is factor metric units.
+func scaledPair(metric, imperial string, factor float64) unitPair {
+	return unitPair{
+		metric, imperial,
+		func(x float64) float64 { return x * factor },
+		func(x float64) float64 { return x / factor },
+	}
+}
+
+var unitPairs = []unitPair{
+	tempPair("Temp", "TempF"),
+	tempPair("FeelsLike", "FeelsLikeF"),
+	tempPair("DewPoint", "DewPointF"),
+	tempPair("HeatIndex", "HeatIndexF"),
+	tempPair("WindChill", "WindChillF"),
+	tempPair("WaterTemp", "WaterTemp_F"),
+	tempPair("MaxTemp", "MaxTempF"),
+	tempPair("MinTemp", "MinTempF"),
+	scaledPair("WindSpeed", "WindSpeedMiles", 1.609344),
+	scaledPair("WindGust", "WindGustMiles", 1.609344),
+	scaledPair("Visibility", "VisibilityMiles", 1.609344),
+	scaledPair("Pressure", "PressureInches", 33.8639),
+	scaledPair("Precip", "PrecipInches", 25.4),
+	scaledPair("SwellHeight", "SwellHeight_ft", 0.3048),
+}
+
+// Fill in the fields of the forecast in the system of units u where only their counterparts were given,
+// converting them, and zero the fields in the other system.
+//
+// This modifies l in place, covering the current conditions, and each day's temperature range and hourly conditions.
+// It is meant for a result as decoded, since Has still reports the fields given in the response afterwards,
+// so normalizing again to the other system leaves those fields zero.
+func (l *Local) NormalizeUnits(u Unit) {
+	normalizeUnits(reflect.ValueOf(&l.Current).Elem(), l.Current.given, u)
+	for i := range l.Weather {
+		w := &l.Weather[i]
+		normalizeUnits(reflect.ValueOf(&w.TempRange).Elem(), nil, u)
+		for j := range w.Condition {
+			normalizeUnits(reflect.ValueOf(&w.Condition[j]).Elem(), w.Condition[j].given, u)
+		}
+	}
+}
+
+// Fill in the fields of the forecast in the system of units u where only their counterparts were given,
+// converting them, and zero the fields in the other system.
+//
+// This modifies m in place, covering each day's temperature range and hourly conditions,
+// and like Local.NormalizeUnits is meant for a result as decoded.
+func (m *Marine) NormalizeUnits(u Unit) {
+	for i := range m.Weather {
+		w := &m.Weather[i]
+		normalizeUnits(reflect.ValueOf(&w.TempRange).Elem(), nil, u)
+		for j := range w.Condition {
+			normalizeUnits(reflect.ValueOf(&w.Condition[j]).Elem(), w.Condition[j].given, u)
+		}
+	}
+}
+
+// Normalize the units of the fields of the struct v, telling given fields from zero ones by the elements given,
+// or if that is nil taking a non-zero field to have been given.
+func normalizeUnits(v reflect.Value, elements elementSet, u Unit) {
+	given := func(name string) bool {
+		if elements != nil {
+			return hasElement(v.Type(), name, elements)
+		}
+		return !v.FieldByName(name).IsZero()
+	}
+
+	for _, p := range unitPairs {
+		keep, drop, convert := p.metric, p.imperial, p.toMetric
+		if u == Imperial {
+			keep, drop, convert = p.imperial, p.metric, p.toImperial
+		}
+
+		kf, df := v.FieldByName(keep), v.FieldByName(drop)
+		if !kf.IsValid() || !df.IsValid() {
+			continue
+		}
+
+		if !given(keep) && given(drop) {
+			setFloat(kf, convert(getFloat(df)))
+		}
+		df.Set(reflect.Zero(df.Type()))
+	}
+}
+
+func getFloat(v reflect.Value) float64 {
+	switch v.Kind() {
+	case reflect.Int:
+		return float64(v.Int())
+	case reflect.Uint:
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+// Set the numeric field v to x, rounding it for integer fields.
+func setFloat(v reflect.Value, x float64) {
+	switch v.Kind() {
+	case reflect.Int:
+		v.SetInt(int64(math.Round(x)))
+	case reflect.Uint:
+		v.SetUint(uint64(math.Max(math.Round(x), 0)))
+	default:
+		v.SetFloat(x)
+	}
+}
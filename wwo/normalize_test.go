@@ -0,0 +1,46 @@
+package wwo
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+// A forecast giving only imperial units.
+const imperialXML = `<data><current_condition><observation_time>12:15 PM</observation_time><temp_F>50</temp_F></current_condition>` +
+	`<weather><date>2024-06-01</date><maxtempF>68</maxtempF><mintempF>41</mintempF>` +
+	`<hourly><time>0</time><tempF>59</tempF><windspeedMiles>10</windspeedMiles><precipInches>0.1</precipInches></hourly>` +
+	`</weather></data>`
+
+func TestNormalizeUnits(t *testing.T) {
+	var decoded Local
+	if err := xml.Unmarshal([]byte(imperialXML), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same forecast after being encoded and decoded again.
+	text, err := xml.Marshal(&decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roundTrip Local
+	if err := xml.Unmarshal(text, &roundTrip); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, l := range map[string]*Local{"decoded": &decoded, "round trip": &roundTrip} {
+		l.NormalizeUnits(Metric)
+
+		w := l.Weather[0]
+		c := w.Condition[0]
+		if l.Current.Temp != 10 || l.Current.TempF != 0 {
+			t.Errorf("%s: current Temp %d, TempF %d, want 10, 0", name, l.Current.Temp, l.Current.TempF)
+		}
+		if w.MaxTemp != 20 || w.MinTemp != 5 || w.MaxTempF != 0 || w.MinTempF != 0 {
+			t.Errorf("%s: TempRange %+v, want 20 to 5 °C only", name, w.TempRange)
+		}
+		if c.Temp != 15 || c.WindSpeed != 16 || c.Precip != 2.54 || c.TempF != 0 || c.WindSpeedMiles != 0 || c.PrecipInches != 0 {
+			t.Errorf("%s: hourly Temp %d, WindSpeed %d, Precip %v, TempF %d, WindSpeedMiles %d, PrecipInches %v, want 15, 16, 2.54 and no imperial units",
+				name, c.Temp, c.WindSpeed, c.Precip, c.TempF, c.WindSpeedMiles, c.PrecipInches)
+		}
+	}
+}
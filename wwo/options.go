@@ -0,0 +1,263 @@
+package wwo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Typed options for a local forecast, as an alternative to the map taken by GetLocal.
+//
+// The zero value of each field leaves the API default in place,
+// so the zero LocalOptions requests the same as an empty map.
+type LocalOptions struct {
+	NumOfDays         *int     // num_of_days      Number of days of forecast to include (0-21), 14 if nil
+	Date              string   // date             Start date of forecast (today, tomorrow, YYYY-mm-dd), tomorrow if empty
+	NoForecast        bool     // fx=no            Exclude the forecast
+	NoCurrent         bool     // cc=no            Exclude current conditions
+	NoMonthlyAverages bool     // mca=no           Exclude monthly averages
+	NoHourly          bool     // fx24=no          Exclude tp-hourly forecasts
+	IncludeLocation   bool     // includelocation  Include nearest location information
+	TP                int      // tp               Number of hours in detailed forecast (1, 3, 6, 12, 24), 3 if zero
+	AirQuality        bool     // aqi=yes          Include air quality
+	Alerts            bool     // alerts=yes       Include severe weather alerts
+	ShowComments      bool     // show_comments    Include forecast commentary
+	Scheme            string   // _scheme          Scheme of the request (http, https), overriding WWO.Insecure, if not empty
+	Extras            Extras   // extra            Extra fields to include
+	Extra             []string // extra            Other extra fields to include, by name
+}
+
+// Extra fields that may be included in conditions, given as the extra option.
+//
+// The corresponding fields of Condition are left zero unless requested.
+type Extras struct {
+	HeatIndex   bool // heatIndex    Condition.HeatIndex and HeatIndexF
+	WindChill   bool // windChill    Condition.WindChill and WindChillF
+	DewPoint    bool // dewPoint     Condition.DewPoint and DewPointF
+	WindGust    bool // windGust     Condition.WindGust and WindGustMiles
+	IsDayTime   bool // isDayTime    Condition.IsDayTime
+	UTCDateTime bool // utcDateTime  Condition.UTCDate and UTCTime
+}
+
+// The names of the extras requested, in the form of the extra option, e.g. "heatIndex,windGust".
+func (e Extras) String() string {
+	return strings.Join(e.names(), ",")
+}
+
+func (e Extras) names() []string {
+	var names []string
+
+	for _, x := range []struct {
+		set  bool
+		name string
+	}{
+		{e.HeatIndex, "heatIndex"},
+		{e.WindChill, "windChill"},
+		{e.DewPoint, "dewPoint"},
+		{e.WindGust, "windGust"},
+		{e.IsDayTime, "isDayTime"},
+		{e.UTCDateTime, "utcDateTime"},
+	} {
+		if x.set {
+			names = append(names, x.name)
+		}
+	}
+
+	return names
+}
+
+// Set the start date of the forecast to the date of t, in t's location.
+func (o *LocalOptions) SetDate(t time.Time) {
+	o.Date = t.Format("2006-01-02")
+}
+
+// The options as a map suitable for GetLocal.
+func (o LocalOptions) Map() map[string]string {
+	var m = make(map[string]string)
+
+	if o.NumOfDays != nil {
+		m["num_of_days"] = strconv.Itoa(*o.NumOfDays)
+	}
+	if o.Date != "" {
+		m["date"] = o.Date
+	}
+	if o.NoForecast {
+		m["fx"] = "no"
+	}
+	if o.NoCurrent {
+		m["cc"] = "no"
+	}
+	if o.NoMonthlyAverages {
+		m["mca"] = "no"
+	}
+	if o.NoHourly {
+		m["fx24"] = "no"
+	}
+	if o.IncludeLocation {
+		m["includelocation"] = "yes"
+	}
+	if o.TP != 0 {
+		m["tp"] = strconv.Itoa(o.TP)
+	}
+	if o.AirQuality {
+		m["aqi"] = "yes"
+	}
+	if o.Alerts {
+		m["alerts"] = "yes"
+	}
+	if o.ShowComments {
+		m["show_comments"] = "yes"
+	}
+	if o.Scheme != "" {
+		m["_scheme"] = o.Scheme
+	}
+	if extra := append(o.Extras.names(), o.Extra...); len(extra) > 0 {
+		m["extra"] = strings.Join(extra, ",")
+	}
+
+	return m
+}
+
+// Typed options for a location search, as an alternative to the map taken by GetSearch.
+//
+// The zero value of each field leaves the API default in place.
+type SearchOptions struct {
+	NumResults      int         // num_of_results  Number of results to return (1-50), 10 if zero
+	IncludeTimezone bool        // timezone=yes    Include timezone information
+	PopularOnly     bool        // popular=yes     Include only popular locations
+	WeatherType     WeatherType // wct             Limit locations to type, any if zero
+}
+
+// Types of location a search may be limited to, given as the wct option.
+type WeatherType int
+
+const (
+	AnyWeatherType WeatherType = iota // No limit
+	SkiType                           // ski
+	CricketType                       // cricket
+	FootballType                      // football
+	GolfType                          // golf
+	FishingType                       // fishing
+)
+
+var weatherTypes = []string{"", "ski", "cricket", "football", "golf", "fishing"}
+
+// The value of the wct option for the type, empty for AnyWeatherType.
+func (t WeatherType) String() string {
+	if t < 0 || int(t) >= len(weatherTypes) {
+		return "WeatherType(" + strconv.Itoa(int(t)) + ")"
+	}
+	return weatherTypes[t]
+}
+
+// The options as a map suitable for GetSearch.
+func (o SearchOptions) Map() map[string]string {
+	var m = make(map[string]string)
+
+	if o.NumResults != 0 {
+		m["num_of_results"] = strconv.Itoa(o.NumResults)
+	}
+	if o.IncludeTimezone {
+		m["timezone"] = "yes"
+	}
+	if o.PopularOnly {
+		m["popular"] = "yes"
+	}
+	if o.WeatherType != AnyWeatherType {
+		m["wct"] = o.WeatherType.String()
+	}
+
+	return m
+}
+
+// A copy of the caller's options with those in set replacing them.
+func override(opt map[string]string, set map[string]string) map[string]string {
+	var m = make(map[string]string, len(opt)+len(set))
+
+	for k, v := range opt {
+		m[k] = v
+	}
+	for k, v := range set {
+		m[k] = v
+	}
+
+	return m
+}
+
+// Check the options that have a known set of values before they are sent.
+func validate(query map[string]string) error {
+	if v, ok := query["_scheme"]; ok && v != "http" && v != "https" {
+		return &OptionError{"_scheme", v}
+	}
+
+	if v, ok := query["tp"]; ok {
+		switch v {
+		case "1", "3", "6", "12", "24":
+		default:
+			return &OptionError{"tp", v}
+		}
+	}
+
+	if v, ok := query["wct"]; ok {
+		switch v {
+		case "ski", "cricket", "football", "golf", "fishing":
+		default:
+			return &OptionError{"wct", v}
+		}
+	}
+
+	if v, ok := query["date"]; ok && v != "today" && v != "tomorrow" {
+		if _, err := time.Parse("2006-01-02", v); err != nil {
+			return &OptionError{"date", v}
+		}
+	}
+
+	if err := validateRange(query, "num_of_days", 0, 21); err != nil {
+		return err
+	}
+	if err := validateRange(query, "num_of_results", 1, 50); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateRange(query map[string]string, key string, min, max int) error {
+	v, ok := query[key]
+	if !ok {
+		return nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < min || n > max {
+		return &OptionError{key, v}
+	}
+
+	return nil
+}
+
+// Check the dates of a past weather request on the plan tier, which needs a start date,
+// and may have an end date no earlier, the span being limited to the month of the start date on the premium plan.
+// Past weather is not offered on the free plan at all.
+func validatePast(tier Tier, opt map[string]string) error {
+	if !tier.offers("past-weather") {
+		return ErrPremiumOnly
+	}
+
+	date, err := time.Parse("2006-01-02", opt["date"])
+	if err != nil {
+		return &OptionError{"date", opt["date"]}
+	}
+
+	v, ok := opt["enddate"]
+	if !ok {
+		return nil
+	}
+
+	end, err := time.Parse("2006-01-02", v)
+	if err != nil || end.Before(date) || end.Year() != date.Year() || end.Month() != date.Month() {
+		return &OptionError{"enddate", v}
+	}
+
+	return nil
+}
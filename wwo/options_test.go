@@ -0,0 +1,212 @@
+package wwo
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLocalOptionsMap(t *testing.T) {
+	var zero, three = 0, 3
+
+	for _, c := range []struct {
+		o    LocalOptions
+		want map[string]string
+	}{
+		{LocalOptions{}, map[string]string{}},
+		{LocalOptions{NumOfDays: &zero}, map[string]string{"num_of_days": "0"}},
+		{LocalOptions{NumOfDays: &three, Date: "today", TP: 24}, map[string]string{"num_of_days": "3", "date": "today", "tp": "24"}},
+		{LocalOptions{NoForecast: true, NoCurrent: true, NoMonthlyAverages: true, NoHourly: true, IncludeLocation: true},
+			map[string]string{"fx": "no", "cc": "no", "mca": "no", "fx24": "no", "includelocation": "yes"}},
+	} {
+		if got := c.o.Map(); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%+v.Map() = %v, want %v", c.o, got, c.want)
+		}
+	}
+}
+
+// A WWO whose requests are counted, answering with an empty response.
+func countingWWO() (*WWO, *cannedTransport) {
+	var rt = &cannedTransport{body: "<data></data>"}
+	return &WWO{Key: "k", HTTPClient: &http.Client{Transport: rt}}, rt
+}
+
+func TestValidateTP(t *testing.T) {
+	var w, rt = countingWWO()
+
+	_, err := w.GetLocal("London", map[string]string{"tp": "5"})
+
+	var oe *OptionError
+	if !errors.As(err, &oe) || oe.Key != "tp" || oe.Value != "5" {
+		t.Errorf("error %v, want an OptionError for tp", err)
+	}
+	if rt.count() != 0 {
+		t.Errorf("%d requests made with an invalid option", rt.count())
+	}
+}
+
+func TestValidatePast(t *testing.T) {
+	var w, rt = countingWWO()
+
+	for _, tt := range []struct {
+		opt map[string]string
+		key string // Option rejected, none if empty
+	}{
+		{map[string]string{"date": "2024-06-01", "enddate": "2024-06-30"}, ""},
+		{map[string]string{"date": "2024-06-01"}, ""},
+		{map[string]string{"date": "2024-06-10", "enddate": "2024-06-01"}, "enddate"},
+		{map[string]string{"date": "2024-06-01", "enddate": "2024-07-01"}, "enddate"},
+		{map[string]string{"date": "2024-06-01", "enddate": "2025-06-02"}, "enddate"},
+		{map[string]string{"enddate": "2024-06-01"}, "date"},
+	} {
+		before := rt.count()
+		_, err := w.GetPastLocal("London", tt.opt)
+
+		var oe *OptionError
+		switch {
+		case tt.key == "" && err != nil:
+			t.Errorf("%v: %v", tt.opt, err)
+		case tt.key != "" && (!errors.As(err, &oe) || oe.Key != tt.key):
+			t.Errorf("%v: error %v, want an OptionError for %s", tt.opt, err, tt.key)
+		case tt.key != "" && rt.count() != before:
+			t.Errorf("%v: request made despite the invalid option", tt.opt)
+		}
+	}
+
+	// The free plan allows no past weather, however short the span.
+	var valid = map[string]string{"date": "2024-06-01", "enddate": "2024-06-02"}
+	if err := validatePast(Free, valid); !errors.Is(err, ErrPremiumOnly) {
+		t.Errorf("validatePast on the free plan: error %v, want ErrPremiumOnly", err)
+	}
+	if err := validatePast(Premium, valid); err != nil {
+		t.Errorf("validatePast on the premium plan: %v", err)
+	}
+}
+
+func TestExtras(t *testing.T) {
+	if got := (Extras{HeatIndex: true, WindGust: true}).String(); got != "heatIndex,windGust" {
+		t.Errorf("Extras.String() = %q, want heatIndex,windGust", got)
+	}
+	if got := (Extras{}).String(); got != "" {
+		t.Errorf("no Extras String() = %q, want empty", got)
+	}
+
+	m := LocalOptions{Extras: Extras{HeatIndex: true, WindGust: true}, Extra: []string{"localObsTime"}}.Map()
+	if m["extra"] != "heatIndex,windGust,localObsTime" {
+		t.Errorf("extra option %q", m["extra"])
+	}
+}
+
+func TestScheme(t *testing.T) {
+	for _, tt := range []struct {
+		insecure bool
+		scheme   string // _scheme option, none if empty
+		want     string
+	}{
+		{false, "", "https"},
+		{true, "", "http"},
+		{false, "http", "http"},
+		{true, "https", "https"},
+	} {
+		var w, rt = countingWWO()
+		w.Insecure = tt.insecure
+
+		var opt = map[string]string{}
+		if tt.scheme != "" {
+			opt["_scheme"] = tt.scheme
+		}
+
+		if _, err := w.GetLocal("London", opt); err != nil {
+			t.Errorf("Insecure %v, _scheme %q: %v", tt.insecure, tt.scheme, err)
+			continue
+		}
+		u, _ := url.Parse(rt.urls[0])
+		if u.Scheme != tt.want {
+			t.Errorf("Insecure %v, _scheme %q: scheme %s, want %s", tt.insecure, tt.scheme, u.Scheme, tt.want)
+		}
+		if u.Query().Has("_scheme") {
+			t.Errorf("Insecure %v, _scheme %q: _scheme sent in %s", tt.insecure, tt.scheme, u)
+		}
+	}
+
+	var w, rt = countingWWO()
+	_, err := w.GetLocal("London", map[string]string{"_scheme": "ftp"})
+
+	var oe *OptionError
+	if !errors.As(err, &oe) || oe.Key != "_scheme" {
+		t.Errorf("error %v, want an OptionError for _scheme", err)
+	}
+	if rt.count() != 0 {
+		t.Errorf("%d requests made with an invalid scheme", rt.count())
+	}
+}
+
+func TestValidateWCT(t *testing.T) {
+	var w, rt = countingWWO()
+
+	for _, wct := range []string{"ski", "cricket", "football", "golf", "fishing"} {
+		if _, err := w.GetSearch("London", map[string]string{"wct": wct}); err != nil {
+			t.Errorf("wct=%s: %v", wct, err)
+		}
+	}
+
+	before := rt.count()
+	for _, tt := range []struct {
+		name string
+		call func() error
+	}{
+		{"GetSearch", func() error {
+			_, err := w.GetSearch("London", map[string]string{"wct": "tennis"})
+			return err
+		}},
+		{"GetSearchOpts", func() error {
+			_, err := w.GetSearchOpts("London", SearchOptions{WeatherType: FishingType + 1})
+			return err
+		}},
+	} {
+		var oe *OptionError
+		if err := tt.call(); !errors.As(err, &oe) || oe.Key != "wct" {
+			t.Errorf("%s: error %v, want an OptionError for wct", tt.name, err)
+		}
+	}
+	if rt.count() != before {
+		t.Errorf("%d requests made with an invalid wct", rt.count()-before)
+	}
+
+	if got := (SearchOptions{WeatherType: GolfType}).Map()["wct"]; got != "golf" {
+		t.Errorf("wct=%q, want golf", got)
+	}
+}
+
+func TestValidateDate(t *testing.T) {
+	var w, rt = countingWWO()
+
+	for _, date := range []string{"today", "tomorrow", "2024-06-01"} {
+		if _, err := w.GetLocal("London", map[string]string{"date": date}); err != nil {
+			t.Errorf("date=%s: %v", date, err)
+		}
+	}
+
+	before := rt.count()
+	_, err := w.GetLocal("London", map[string]string{"date": "someday"})
+
+	var oe *OptionError
+	if !errors.As(err, &oe) || oe.Key != "date" || oe.Value != "someday" {
+		t.Errorf("error %v, want an OptionError for date", err)
+	}
+	if !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("error %v, want ErrInvalidOption", err)
+	}
+	if rt.count() != before {
+		t.Error("request made with an invalid date")
+	}
+
+	var o LocalOptions
+	o.SetDate(time.Date(2024, 6, 1, 23, 30, 0, 0, time.FixedZone("", -5*3600)))
+	if got := o.Map()["date"]; got != "2024-06-01" {
+		t.Errorf("date=%q, want 2024-06-01", got)
+	}
+}
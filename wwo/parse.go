@@ -0,0 +1,127 @@
+package wwo
+
+import (
+	"fmt"
+	"io"
+)
+
+// Read all of the response from r.
+func readResponse(service string, r io.Reader) ([]byte, error) {
+	text, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("wwo: reading %s response: %w", service, err)
+	}
+	return text, nil
+}
+
+// Parse a local forecast response read from r, as kept from the Raw field of a result.
+// It may be XML or JSON, which is told from the start of the response.
+func ParseLocal(r io.Reader) (*Local, error) {
+	text, err := readResponse("weather", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var o *Local = new(Local)
+	if err := decode(sniffFormat(text), "weather", text, o); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// Parse a marine forecast response read from r, as kept from the Raw field of a result.
+// It may be XML or JSON, which is told from the start of the response.
+func ParseMarine(r io.Reader) (*Marine, error) {
+	text, err := readResponse("marine", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var o *Marine = new(Marine)
+	if err := decode(sniffFormat(text), "marine", text, o); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// Parse a ski forecast response read from r, as kept from the Raw field of a result.
+// It may be XML or JSON, which is told from the start of the response.
+func ParseSki(r io.Reader) (*Ski, error) {
+	text, err := readResponse("ski", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var o *Ski = new(Ski)
+	if err := decode(sniffFormat(text), "ski", text, o); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// Parse a historical local weather report response read from r, as kept from the Raw field of a result.
+// It may be XML or JSON, which is told from the start of the response.
+func ParsePastLocal(r io.Reader) (*PastLocal, error) {
+	text, err := readResponse("past-weather", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var o *PastLocal = new(PastLocal)
+	if err := decode(sniffFormat(text), "past-weather", text, o); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// Parse a historical marine weather report response read from r, as kept from the Raw field of a result.
+// It may be XML or JSON, which is told from the start of the response.
+func ParsePastMarine(r io.Reader) (*PastMarine, error) {
+	text, err := readResponse("past-marine", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var o *PastMarine = new(PastMarine)
+	if err := decode(sniffFormat(text), "past-marine", text, o); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// Parse a location search response read from r, as kept from the Raw field of a result.
+// It may be XML or JSON, which is told from the start of the response.
+func ParseSearch(r io.Reader) (*Search, error) {
+	text, err := readResponse("search", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var o *Search = new(Search)
+	if err := decode(sniffFormat(text), "search", text, o); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// Parse a time zone lookup response read from r, as kept from the Raw field of a result.
+// It may be XML or JSON, which is told from the start of the response.
+func ParseTimeZone(r io.Reader) (*TimeZone, error) {
+	text, err := readResponse("tz", r)
+	if err != nil {
+		return nil, err
+	}
+
+	var o *TimeZone = new(TimeZone)
+	if err := decode(sniffFormat(text), "tz", text, o); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
@@ -0,0 +1,37 @@
+package wwo
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLocal(t *testing.T) {
+	var w = testWWO(t, respond(localXML))
+	w.KeepRaw = true
+
+	live, err := w.GetLocal("London", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParseLocal(bytes.NewReader(live.Raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the fields set by the WWO differ.
+	live.Raw, live.Options = nil, nil
+	if !reflect.DeepEqual(parsed, live) {
+		t.Errorf("ParseLocal() = %+v, want %+v", parsed, live)
+	}
+
+	if l, err := ParseLocal(strings.NewReader(localJSON)); err != nil || len(l.Weather) == 0 {
+		t.Errorf("ParseLocal() of JSON = %+v, %v", l, err)
+	}
+	if _, err := ParseLocal(strings.NewReader(notFoundXML)); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("ParseLocal() of an error response: error %v, want ErrLocationNotFound", err)
+	}
+}
@@ -0,0 +1,206 @@
+package wwo
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Whether the named field of the conditions, e.g. "Temp", was given in the response,
+// telling a zero value from a missing one.
+//
+// This is known only for conditions decoded from a response,
+// as the elements given are not kept in JSON, so after a JSON round trip Has reports no fields.
+func (c *Condition) Has(field string) bool {
+	return hasElement(reflect.TypeOf(*c), field, c.given)
+}
+
+// Whether the named field of the current conditions, e.g. "Temp", was given in the response,
+// telling a zero value from a missing one, as with Condition.Has.
+func (c *CurrentCondition) Has(field string) bool {
+	return hasElement(reflect.TypeOf(*c), field, c.given)
+}
+
+// The names of the elements given within an element of a response.
+type elementSet map[string]bool
+
+// Whether the element of the named field of struct type t is among those given.
+func hasElement(t reflect.Type, field string, given elementSet) bool {
+	f, ok := t.FieldByName(field)
+	if !ok {
+		return false
+	}
+
+	name := elementName(f)
+	return name != "" && given[name]
+}
+
+// The name of the element of a field, empty if it has none of its own.
+func elementName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("xml"), ",")[0]
+	if name == "-" || strings.Contains(name, ">") {
+		return ""
+	}
+	return name
+}
+
+// Decoded as usual, recording the elements given for Has.
+func (c *Condition) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeGiven(d, start, c, &c.given)
+}
+
+// Decoded as usual, recording the elements given for Has.
+//
+// This and the other conditions embedding Condition need their own method,
+// as the one promoted from Condition would decode only its fields.
+func (c *CurrentCondition) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeGiven(d, start, c, &c.given)
+}
+
+// Decoded as usual, recording the elements given for Has.
+func (c *ForecastCondition) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeGiven(d, start, c, &c.given)
+}
+
+// Decoded as usual, recording the elements given for Has.
+func (c *MarineCondition) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeGiven(d, start, c, &c.given)
+}
+
+// Decode the element start into v, a pointer to a struct, as it would be without its UnmarshalXML method,
+// setting given to the names of the elements within it.
+//
+// Converting v to a type without the method does not do, as the struct may embed Condition, which has one,
+// so v's fields are decoded through a struct type with the same fields and no methods.
+func decodeGiven(d *xml.Decoder, start xml.StartElement, v interface{}, given *elementSet) error {
+	var rv = reflect.ValueOf(v).Elem()
+	var p = plainStructOf(rv.Type())
+
+	var plain = reflect.New(p.typ).Elem()
+	for i, index := range p.index {
+		plain.Field(i).Set(rv.FieldByIndex(index))
+	}
+
+	var r = &elementRecorder{d: d, start: &start, names: make(elementSet)}
+	err := xml.NewTokenDecoder(r).Decode(plain.Addr().Interface())
+
+	for i, index := range p.index {
+		rv.FieldByIndex(index).Set(plain.Field(i))
+	}
+	*given = r.names
+
+	return err
+}
+
+// A struct type with the exported fields of another, including those of embedded structs,
+// and the index of each in the other.
+type plainStruct struct {
+	typ   reflect.Type
+	index [][]int
+}
+
+var plainStructs sync.Map // Of reflect.Type to *plainStruct
+
+func plainStructOf(t reflect.Type) *plainStruct {
+	if p, ok := plainStructs.Load(t); ok {
+		return p.(*plainStruct)
+	}
+
+	var p = new(plainStruct)
+	var fields []reflect.StructField
+
+	for _, f := range reflect.VisibleFields(t) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		fields = append(fields, reflect.StructField{Name: f.Name, Type: f.Type, Tag: f.Tag})
+		p.index = append(p.index, f.Index)
+	}
+	p.typ = reflect.StructOf(fields)
+
+	plainStructs.Store(t, p)
+	return p
+}
+
+// Reads the tokens of the element start from d, starting with start itself,
+// recording the names of the elements immediately within it.
+type elementRecorder struct {
+	d     *xml.Decoder
+	start *xml.StartElement // Not yet returned if not nil
+	depth int
+	names elementSet
+}
+
+func (r *elementRecorder) Token() (xml.Token, error) {
+	if r.start != nil {
+		start := *r.start
+		r.start = nil
+		r.depth++
+		return start, nil
+	}
+
+	tok, err := r.d.Token()
+	switch tok := tok.(type) {
+	case xml.StartElement:
+		if r.depth == 1 {
+			r.names[tok.Name.Local] = true
+		}
+		r.depth++
+	case xml.EndElement:
+		r.depth--
+	}
+	return tok, err
+}
+
+// Conditions decoded from a response are encoded with only the elements it gave,
+// so they decode again to the same fields, and Has reports the same.
+// Conditions made otherwise, with no elements recorded, are encoded with every field,
+// unless they are zero, when they are left out as they were not in the response.
+func (c Condition) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGiven(e, start, reflect.ValueOf(c), c.given)
+}
+
+// Encoded with only the elements given in the response, as with Condition.
+func (c CurrentCondition) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGiven(e, start, reflect.ValueOf(c), c.given)
+}
+
+// Encoded with only the elements given in the response, as with Condition.
+func (c ForecastCondition) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGiven(e, start, reflect.ValueOf(c), c.given)
+}
+
+// Encoded with only the elements given in the response, as with Condition.
+func (c MarineCondition) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalGiven(e, start, reflect.ValueOf(c), c.given)
+}
+
+// Encode the fields of the struct v, including those of embedded structs, as the element start,
+// leaving out those not among given unless it is nil, and the whole element if v is zero.
+func marshalGiven(e *xml.Encoder, start xml.StartElement, v reflect.Value, given elementSet) error {
+	if given == nil && v.IsZero() {
+		return nil
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+
+		name := elementName(f)
+		if name == "" || (given != nil && !given[name]) {
+			continue
+		}
+
+		if err := e.EncodeElement(v.FieldByIndex(f.Index).Interface(), xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
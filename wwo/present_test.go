@@ -0,0 +1,60 @@
+package wwo
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+)
+
+func TestHas(t *testing.T) {
+	var omitted, zero CurrentCondition
+	if err := xml.Unmarshal([]byte(`<current_condition><humidity>80</humidity></current_condition>`), &omitted); err != nil {
+		t.Fatal(err)
+	}
+	if err := xml.Unmarshal([]byte(`<current_condition><temp_C>0</temp_C><humidity>80</humidity></current_condition>`), &zero); err != nil {
+		t.Fatal(err)
+	}
+
+	if omitted.Temp != 0 || zero.Temp != 0 {
+		t.Fatalf("Temp = %d and %d, want both zero", omitted.Temp, zero.Temp)
+	}
+	if omitted.Has("Temp") {
+		t.Error("Has(Temp) with temp_C omitted")
+	}
+	if !zero.Has("Temp") {
+		t.Error("not Has(Temp) with temp_C sent as 0")
+	}
+	if !omitted.Has("Humidity") || omitted.Has("NoSuchField") || omitted.Has("given") {
+		t.Error("Has wrong for Humidity, or for fields with no element")
+	}
+
+	var h ForecastCondition
+	if err := xml.Unmarshal([]byte(`<hourly><tempC>0</tempC><WindChillC>-3</WindChillC></hourly>`), &h); err != nil {
+		t.Fatal(err)
+	}
+	if !h.Has("Temp") || !h.Has("WindChill") || h.Has("DewPoint") {
+		t.Error("Has wrong for hourly conditions")
+	}
+
+	// The fields of the conditions embedding Condition are decoded along with its own.
+	var m MarineCondition
+	if err := xml.Unmarshal([]byte(`<hourly><tempC>14</tempC><sigHeight_m>1.5</sigHeight_m></hourly>`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Temp != 14 || m.SigHeight != 1.5 || !m.Has("Temp") || m.Has("Humidity") {
+		t.Errorf("marine conditions %+v", m)
+	}
+
+	// The elements given are not kept in JSON.
+	text, err := json.Marshal(zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded CurrentCondition
+	if err := json.Unmarshal(text, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Has("Temp") {
+		t.Error("Has(Temp) after a JSON round trip")
+	}
+}
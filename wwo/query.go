@@ -0,0 +1,82 @@
+package wwo
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// A location query for coordinates, to four decimal places, e.g. "51.5074,-0.1278".
+func Coords(lat, lon float64) string {
+	return strconv.FormatFloat(lat, 'f', 4, 64) + "," + strconv.FormatFloat(lon, 'f', 4, 64)
+}
+
+// A location query for the area's coordinates, as with Coords.
+func (a Area) Query() string {
+	return Coords(a.Latitude, a.Longitude)
+}
+
+// The area's page on the WorldWeatherOnline site, from WeatherURL,
+// which must be an absolute http or https URL.
+func (a Area) WeatherPageURL() (*url.URL, error) {
+	u, err := url.Parse(a.WeatherURL)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("wwo: weather URL is not an http or https URL")
+	}
+	return u, nil
+}
+
+// Fetch a local forecast for a latitude and longitude, as with GetLocal.
+func (w *WWO) GetLocalByCoords(lat, lon float64, opt map[string]string) (*Local, error) {
+	return w.GetLocal(Coords(lat, lon), opt)
+}
+
+// Fetch a marine forecast for a latitude and longitude, as with GetMarine.
+func (w *WWO) GetMarineByCoords(lat, lon float64, opt map[string]string) (*Marine, error) {
+	return w.GetMarine(Coords(lat, lon), opt)
+}
+
+// Fetch a ski forecast for a latitude and longitude, as with GetSki.
+func (w *WWO) GetSkiByCoords(lat, lon float64, opt map[string]string) (*Ski, error) {
+	return w.GetSki(Coords(lat, lon), opt)
+}
+
+// Fetch a local forecast for an IP address, as with GetLocal.
+//
+// An error is returned with the forecast if the API took the query as another kind of location.
+func (w *WWO) GetLocalByIP(ip net.IP, opt map[string]string) (*Local, error) {
+	if ip == nil {
+		return nil, &OptionError{"q", ""}
+	}
+
+	o, err := w.GetLocal(ip.String(), opt)
+	if err == nil && o.Request.Type != "" && o.Request.Type != "IP" {
+		err = errors.New("wwo: query taken as " + o.Request.Type + ", not an IP address")
+	}
+	return o, err
+}
+
+// Fetch a local forecast for a UK or Canadian postcode, or US zip code, as with GetLocal.
+// The country, if given, is appended to the query to help the API place the code.
+//
+// An error is returned with the forecast if the API took the query as another kind of location.
+func (w *WWO) GetLocalByPostcode(code, country string, opt map[string]string) (*Local, error) {
+	q := strings.TrimSpace(code)
+	if q == "" {
+		return nil, &OptionError{"q", code}
+	}
+	if country != "" {
+		q += "," + country
+	}
+
+	o, err := w.GetLocal(q, opt)
+	if err == nil && o.Request.Type != "" && !strings.Contains(strings.ToLower(o.Request.Type), "code") {
+		err = errors.New("wwo: query taken as " + o.Request.Type + ", not a postcode")
+	}
+	return o, err
+}
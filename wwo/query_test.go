@@ -0,0 +1,91 @@
+package wwo
+
+import (
+	"net"
+	"testing"
+)
+
+func TestCoords(t *testing.T) {
+	for _, tt := range []struct {
+		lat, lon float64
+		want     string
+	}{
+		{51.5074, -0.1278, "51.5074,-0.1278"},
+		{-33.86882, 151.20929, "-33.8688,151.2093"},
+		{0, 0, "0.0000,0.0000"},
+	} {
+		if got := Coords(tt.lat, tt.lon); got != tt.want {
+			t.Errorf("Coords(%v, %v) = %q, want %q", tt.lat, tt.lon, got, tt.want)
+		}
+	}
+
+	var a = Area{Latitude: 51.5074, Longitude: -0.1278}
+	if got := a.Query(); got != "51.5074,-0.1278" {
+		t.Errorf("Area.Query() = %q", got)
+	}
+}
+
+func TestWeatherPageURL(t *testing.T) {
+	u, err := Area{WeatherURL: "https://www.worldweatheronline.com/london-weather/city-of-london-greater-london/gb.aspx"}.WeatherPageURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Host != "www.worldweatheronline.com" || u.Path != "/london-weather/city-of-london-greater-london/gb.aspx" {
+		t.Errorf("WeatherPageURL() host %q, path %q", u.Host, u.Path)
+	}
+
+	for _, s := range []string{"javascript:alert(1)", "ftp://www.worldweatheronline.com/", "/london-weather", ""} {
+		if u, err := (Area{WeatherURL: s}).WeatherPageURL(); err == nil {
+			t.Errorf("WeatherPageURL() of %q = %v, want an error", s, u)
+		}
+	}
+}
+
+func TestGetLocalByIP(t *testing.T) {
+	var h = &recorder{body: `<data><request><type>IP</type><query>Host: 81.2.69.160</query></request></data>`}
+	var w = testWWO(t, h)
+
+	if _, err := w.GetLocalByIP(net.ParseIP("81.2.69.160"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.last().Query().Get("q"); got != "81.2.69.160" {
+		t.Errorf("q=%q sent, want 81.2.69.160", got)
+	}
+
+	// An IPv4 address parsed as IPv6.
+	if _, err := w.GetLocalByIP(net.IPv4(81, 2, 69, 160), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.last().Query().Get("q"); got != "81.2.69.160" {
+		t.Errorf("q=%q sent, want 81.2.69.160", got)
+	}
+
+	h.body = currentXML
+	if _, err := w.GetLocalByIP(net.ParseIP("81.2.69.160"), nil); err == nil {
+		t.Error("no error for a query taken as a city")
+	}
+}
+
+func TestGetLocalByPostcode(t *testing.T) {
+	var h = &recorder{body: `<data><request><type>UK Postcode</type><query>SW1A 2AA</query></request></data>`}
+	var w = testWWO(t, h)
+
+	for _, tt := range []struct {
+		code, country string
+		want          string
+	}{
+		{"SW1A 2AA", "", "SW1A 2AA"},
+		{" SW1A 2AA ", "UK", "SW1A 2AA,UK"},
+	} {
+		if _, err := w.GetLocalByPostcode(tt.code, tt.country, nil); err != nil {
+			t.Fatal(err)
+		}
+		if got := h.last().Query().Get("q"); got != tt.want {
+			t.Errorf("GetLocalByPostcode(%q, %q): q=%q sent, want %q", tt.code, tt.country, got, tt.want)
+		}
+	}
+
+	if _, err := w.GetLocalByPostcode(" ", "UK", nil); err == nil {
+		t.Error("no error for an empty postcode")
+	}
+}
@@ -0,0 +1,124 @@
+package wwo
+
+// The snow of a day's ski forecast.
+//
+// Snowfall is given for the whole resort rather than for each elevation band.
+type SnowSummary struct {
+	Chance       uint    // %   Chance of snow
+	Total        float64 // cm  Total snowfall amount for the day
+	HourlyTotal  float64 // cm  Total of the hourly conditions' snowfall
+	SnowyPeriods int     //     Number of hourly conditions with snowfall
+}
+
+// The snow of the day's forecast, from its separate fields and hourly conditions.
+func (w *SkiWeather) SnowSummary() SnowSummary {
+	var s = SnowSummary{Chance: w.ChanceSnow, Total: w.TotalSnow}
+
+	for i := range w.Condition {
+		if c := &w.Condition[i]; c.Snowfall > 0 {
+			s.HourlyTotal += c.Snowfall
+			s.SnowyPeriods++
+		}
+	}
+
+	return s
+}
+
+// The freeze level in feet, from FreezeLevel.
+func (c *SkiCondition) FreezeLevelFt() float64 {
+	return float64(c.FreezeLevel) / 0.3048
+}
+
+// The bands, of "top", "mid", and "bottom", at or above the freeze level, so below freezing,
+// given the elevations of the bands in metres, which the API does not give.
+func (c *SkiCondition) FreezingBands(top, mid, bottom float64) []string {
+	var bands []string
+
+	for _, b := range []struct {
+		name      string
+		elevation float64
+	}{
+		{"top", top},
+		{"mid", mid},
+		{"bottom", bottom},
+	} {
+		if b.elevation >= float64(c.FreezeLevel) {
+			bands = append(bands, b.name)
+		}
+	}
+
+	return bands
+}
+
+// Temperature at the top of the resort in the system of units u, with its unit symbol.
+func (c *SkiCondition) TopTemp(u Unit) (int, string) {
+	return c.Top.TempAs(u)
+}
+
+// Temperature at the middle of the resort in the system of units u, with its unit symbol.
+func (c *SkiCondition) MidTemp(u Unit) (int, string) {
+	return c.Mid.TempAs(u)
+}
+
+// Temperature at the bottom of the resort in the system of units u, with its unit symbol.
+func (c *SkiCondition) BottomTemp(u Unit) (int, string) {
+	return c.Bottom.TempAs(u)
+}
+
+// Weights of the parts of a day's skiability score, as used by SkiWeather.Score.
+type SkiWeights struct {
+	FreshSnow  float64 // Per cm of the day's total snowfall
+	ChanceSnow float64 // Per % chance of snow
+	Wind       float64 // Per km/hr of the highest wind speed at the top, usually negative
+	Visibility float64 // Per km of the average visibility
+}
+
+// Weights favouring fresh snow, and then calm, clear days.
+var DefaultSkiWeights = SkiWeights{FreshSnow: 2, ChanceSnow: 0.2, Wind: -0.5, Visibility: 0.5}
+
+// The skiability score of the day, the sum of the weighted snowfall, chance of snow,
+// highest wind speed at the top, and average visibility of the hourly conditions.
+func (w *SkiWeather) Score(weights SkiWeights) float64 {
+	var wind uint
+	var visibility float64
+
+	for i := range w.Condition {
+		c := &w.Condition[i]
+		if c.Top.WindSpeed > wind {
+			wind = c.Top.WindSpeed
+		}
+		visibility += float64(c.Visibility)
+	}
+	if len(w.Condition) > 0 {
+		visibility /= float64(len(w.Condition))
+	}
+
+	return weights.FreshSnow*w.TotalSnow +
+		weights.ChanceSnow*float64(w.ChanceSnow) +
+		weights.Wind*float64(wind) +
+		weights.Visibility*visibility
+}
+
+// The day with the highest score with DefaultSkiWeights, and its index,
+// the earliest if tied, or nil and -1 if there are no days.
+func (s *Ski) BestDay() (*SkiWeather, int) {
+	return s.BestDayBy(DefaultSkiWeights)
+}
+
+// The day with the highest score with weights, and its index,
+// the earliest if tied, or nil and -1 if there are no days.
+func (s *Ski) BestDayBy(weights SkiWeights) (*SkiWeather, int) {
+	var best = -1
+	var bestScore float64
+
+	for i := range s.Weather {
+		if score := s.Weather[i].Score(weights); best < 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	if best < 0 {
+		return nil, -1
+	}
+	return &s.Weather[best], best
+}
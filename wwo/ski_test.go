@@ -0,0 +1,102 @@
+package wwo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSnowSummary(t *testing.T) {
+	var w = SkiWeather{ChanceSnow: 85, TotalSnow: 12.5}
+	for _, cm := range []float64{0, 2.5, 0, 4, 1.5} {
+		var c SkiCondition
+		c.Snowfall = cm
+		w.Condition = append(w.Condition, c)
+	}
+
+	want := SnowSummary{Chance: 85, Total: 12.5, HourlyTotal: 8, SnowyPeriods: 3}
+	if got := w.SnowSummary(); got != want {
+		t.Errorf("SnowSummary() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFreezingBands(t *testing.T) {
+	var c = SkiCondition{FreezeLevel: 2000}
+
+	if got := c.FreezeLevelFt(); math.Abs(got-6561.68) > 0.01 {
+		t.Errorf("FreezeLevelFt() = %v, want 6561.68", got)
+	}
+
+	for _, tt := range []struct {
+		top, mid, bottom float64
+		want             []string
+	}{
+		{3300, 2300, 1500, []string{"top", "mid"}},
+		{3300, 2000, 1500, []string{"top", "mid"}},
+		{1900, 1400, 1000, nil},
+		{3800, 3000, 2100, []string{"top", "mid", "bottom"}},
+	} {
+		if got := c.FreezingBands(tt.top, tt.mid, tt.bottom); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("FreezingBands(%v, %v, %v) = %v, want %v", tt.top, tt.mid, tt.bottom, got, tt.want)
+		}
+	}
+}
+
+func TestLevelTemps(t *testing.T) {
+	var c = SkiCondition{
+		Top:    LevelCond{Temp: -8, TempF: 18},
+		Mid:    LevelCond{Temp: -3, TempF: 27},
+		Bottom: LevelCond{Temp: 2, TempF: 36},
+	}
+
+	for _, tt := range []struct {
+		name string
+		temp func(Unit) (int, string)
+		c, f int
+	}{
+		{"TopTemp", c.TopTemp, -8, 18},
+		{"MidTemp", c.MidTemp, -3, 27},
+		{"BottomTemp", c.BottomTemp, 2, 36},
+	} {
+		if temp, unit := tt.temp(Imperial); temp != tt.f || unit != "°F" {
+			t.Errorf("%s(Imperial) = %d, %q, want %d, °F", tt.name, temp, unit, tt.f)
+		}
+		if temp, unit := tt.temp(Metric); temp != tt.c || unit != "°C" {
+			t.Errorf("%s(Metric) = %d, %q, want %d, °C", tt.name, temp, unit, tt.c)
+		}
+	}
+}
+
+func TestBestDay(t *testing.T) {
+	var s Ski
+	for _, d := range []struct {
+		snow              float64
+		chance, wind, vis uint
+	}{
+		{0, 10, 40, 10},
+		{25, 90, 15, 8},
+		{2, 30, 20, 10},
+	} {
+		var w = SkiWeather{TotalSnow: d.snow, ChanceSnow: d.chance}
+		var c SkiCondition
+		c.Top.WindSpeed, c.Visibility = d.wind, d.vis
+		w.Condition = append(w.Condition, c)
+		s.Weather = append(s.Weather, w)
+	}
+
+	if w, i := s.BestDay(); i != 1 || w != &s.Weather[1] {
+		t.Errorf("BestDay() = %p, %d, want the second day", w, i)
+	}
+
+	// Weights favouring only calm days.
+	if _, i := s.BestDayBy(SkiWeights{Wind: -1}); i != 1 {
+		t.Errorf("BestDayBy(calm) index %d, want 1", i)
+	}
+	if _, i := s.BestDayBy(SkiWeights{Visibility: 1, Wind: -0.1}); i != 2 {
+		t.Errorf("BestDayBy(clear) index %d, want 2", i)
+	}
+
+	if w, i := new(Ski).BestDay(); w != nil || i != -1 {
+		t.Errorf("BestDay() of no days = %p, %d, want nil, -1", w, i)
+	}
+}
@@ -2,6 +2,8 @@ package wwo
 
 import (
 	"encoding/xml"
+	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -24,6 +26,20 @@ func (t Date) String() string {
 	return time.Time(t).Format("2006-01-02")
 }
 
+func (t Date) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *Date) UnmarshalText(text []byte) error {
+	ti, err := time.Parse("2006-01-02", string(text))
+	*t = Date(ti)
+	return err
+}
+
+func (t Date) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(t.String(), start)
+}
+
 // Times of tides, sun/moon rise/set, are given in local time without a date.
 type Time12 time.Duration
 
@@ -40,14 +56,75 @@ func (t *Time12) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	}
 
 	ti, err := time.Parse("3:04 PM", content)
-	*t = Time12(ti.Sub(time.Time{}))
+	*t = Time12(time.Duration(ti.Hour())*time.Hour + time.Duration(ti.Minute())*time.Minute)
 	return err
 }
 
+// Encoded as given by the API, with "No event" for the value given for no moonrise, etc.
+func (t Time12) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !t.Valid() {
+		return e.EncodeElement("No event", start)
+	}
+	return e.EncodeElement((time.Time{}).Add(time.Duration(t)).Format("03:04 PM"), start)
+}
+
 func (t Time12) String() string {
+	if !t.Valid() {
+		return "No event"
+	}
 	return (time.Time{}).Add(time.Duration(t)).Format("15:04")
 }
 
+// The "No event" value is encoded as "No event" in text, as null in JSON.
+func (t Time12) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *Time12) UnmarshalText(text []byte) error {
+	if strings.HasPrefix(string(text), "No ") {
+		*t = Time12(-1)
+		return nil
+	}
+
+	ti, err := time.Parse("15:04", string(text))
+	*t = Time12(time.Duration(ti.Hour())*time.Hour + time.Duration(ti.Minute())*time.Minute)
+	return err
+}
+
+func (t Time12) MarshalJSON() ([]byte, error) {
+	if !t.Valid() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.Quote(t.String())), nil
+}
+
+func (t *Time12) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = Time12(-1)
+		return nil
+	}
+
+	text, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(text))
+}
+
+// Whether t is a time, rather than the "No event" value given for no moonrise, etc.
+func (t Time12) Valid() bool {
+	return t >= 0
+}
+
+// The absolute time of t on day d in zone z, which is UTC if nil.
+// For the "No event" value the zero time is returned with ok false.
+func (t Time12) On(d Date, z *Zone) (ti time.Time, ok bool) {
+	if !t.Valid() {
+		return time.Time{}, false
+	}
+	return startOfDay(d, z).Add(time.Duration(t)), true
+}
+
 // Times of forecast and historical detailed conditions as an integer representing local time.
 type TimeHMM time.Duration
 
@@ -62,174 +139,379 @@ func (t *TimeHMM) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	return err
 }
 
+func (t TimeHMM) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	d := time.Duration(t)
+	h, m := d/time.Hour, d%time.Hour/time.Minute
+	return e.EncodeElement(strconv.Itoa(int(h*100+m)), start)
+}
+
 func (t TimeHMM) String() string {
 	return (time.Time{}).Add(time.Duration(t)).Format("15:04")
 }
 
+func (t TimeHMM) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *TimeHMM) UnmarshalText(text []byte) error {
+	ti, err := time.Parse("15:04", string(text))
+	*t = TimeHMM(time.Duration(ti.Hour())*time.Hour + time.Duration(ti.Minute())*time.Minute)
+	return err
+}
+
+// The absolute time of t on day d in zone z, which is UTC if nil.
+func (t TimeHMM) On(d Date, z *Zone) time.Time {
+	return startOfDay(d, z).Add(time.Duration(t))
+}
+
+// Midnight at the start of day d in zone z.
+func startOfDay(d Date, z *Zone) time.Time {
+	y, m, day := time.Time(d).Date()
+	return time.Date(y, m, day, 0, 0, 0, 0, z.Location())
+}
+
+// Alerts give their times as full timestamps with an offset from UTC.
+type DateTime time.Time
+
+func (t *DateTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var content string
+	if err := d.DecodeElement(&content, &start); err != nil {
+		return err
+	}
+
+	// Not every alert has an expiry.
+	if content == "" {
+		*t = DateTime{}
+		return nil
+	}
+
+	ti, err := time.Parse(time.RFC3339, content)
+	*t = DateTime(ti)
+	return err
+}
+
+func (t DateTime) String() string {
+	return time.Time(t).Format(time.RFC3339)
+}
+
+// The zero time is encoded as empty text, or null in JSON.
+func (t DateTime) MarshalText() ([]byte, error) {
+	if time.Time(t).IsZero() {
+		return nil, nil
+	}
+	return []byte(t.String()), nil
+}
+
+func (t *DateTime) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = DateTime{}
+		return nil
+	}
+
+	ti, err := time.Parse(time.RFC3339, string(text))
+	*t = DateTime(ti)
+	return err
+}
+
+func (t DateTime) MarshalJSON() ([]byte, error) {
+	if time.Time(t).IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.Quote(t.String())), nil
+}
+
+// Flags given as yes or no.
+type YesNo bool
+
+func (f *YesNo) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var content string
+	if err := d.DecodeElement(&content, &start); err != nil {
+		return err
+	}
+	*f = YesNo(strings.EqualFold(content, "yes"))
+	return nil
+}
+
+func (f YesNo) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(f.String(), start)
+}
+
+func (f YesNo) String() string {
+	if f {
+		return "yes"
+	}
+	return "no"
+}
+
 // Most queries include the request that generated them.
 type Request struct {
-	Query string `xml:"query"` // The location query used
-	Type  string `xml:"type"`  // The type of location request
+	Query string `xml:"query" json:"query"` // The location query used
+	Type  string `xml:"type" json:"type"`   // The type of location request
 }
 
 // Describes an area known to WorldWeatherOnline
+//
+// In forecasts this is the nearest_area element added by includelocation=yes,
+// the weather point the data is for, which may differ from the location queried,
+// rather than a separate location block.
 type Area struct {
-	Country    string  `xml:"country"`
-	Latitude   float64 `xml:"latitude"`
-	Longitude  float64 `xml:"longitude"`
-	Name       string  `xml:"areaName"`
-	Region     string  `xml:"region"`
-	Population uint    `xml:"population"`     //      Location's population
-	DistanceMI float64 `xml:"distance_miles"` // mi   Distance between query point and this area
-	WeatherURL string  `xml:"weatherUrl"`
-	Zone       *Zone   `xml:"timezone"`
+	Country    string  `xml:"country" json:"country"`
+	Latitude   float64 `xml:"latitude" json:"latitude"`
+	Longitude  float64 `xml:"longitude" json:"longitude"`
+	Name       string  `xml:"areaName" json:"name"`
+	Region     string  `xml:"region" json:"region"`
+	Population uint    `xml:"population" json:"population"`         //      Location's population
+	DistanceMI float64 `xml:"distance_miles" json:"distance_miles"` // mi   Distance between query point and this area
+	WeatherURL string  `xml:"weatherUrl" json:"weather_url"`
+	Zone       *Zone   `xml:"timezone" json:"zone,omitempty"`
+}
+
+// The zero Area, as in forecasts not requested with includelocation=yes, is not encoded.
+func (a Area) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if a == (Area{}) {
+		return nil
+	}
+
+	type area Area // Without this method
+	return e.EncodeElement(area(a), start)
 }
 
 // A range of temperatures in a given period of time
 type TempRange struct {
-	MaxTemp  int `xml:"maxtempC"` // °C  Maximum temperature
-	MaxTempF int `xml:"maxtempF"` // °F  Maximum temperature
-	MinTemp  int `xml:"mintempC"` // °C  Minimum temperature
-	MinTempF int `xml:"mintempF"` // °F  Minimum temperature
+	MaxTemp  int `xml:"maxtempC" json:"max_temp_c"` // °C  Maximum temperature
+	MaxTempF int `xml:"maxtempF" json:"max_temp_f"` // °F  Maximum temperature
+	MinTemp  int `xml:"mintempC" json:"min_temp_c"` // °C  Minimum temperature
+	MinTempF int `xml:"mintempF" json:"min_temp_f"` // °F  Minimum temperature
 }
 
 // The common fields of weather reports.
 type Weather struct {
 	TempRange
-	Astronomy Astronomy   `xml:"astronomy"`    // Astronomical information for the day
-	Date      Date        `xml:"date"`         // Date of forecast
-	SunHour   float64     `xml:"sunHour"`      // Total sun in hours
-	TotalSnow float64     `xml:"totalSnow_cm"` // Total snowfall amount in cm
-	UVIndex   uint        `xml:"uvIndex"`      // UV Index
-	Condition []Condition `xml:"hourly"`       // Weather conditions
+	Astronomy Astronomy   `xml:"astronomy" json:"astronomy"`                 // Astronomical information for the day
+	Date      Date        `xml:"date" json:"date"`                           // Date of forecast
+	SunHour   float64     `xml:"sunHour" json:"sun_hour"`                    // Total sun in hours
+	TotalSnow float64     `xml:"totalSnow_cm" json:"total_snow_cm"`          // Total snowfall amount in cm
+	UVIndex   uint        `xml:"uvIndex" json:"uv_index"`                    // UV Index
+	Condition []Condition `xml:"hourly" json:"hourly"`                       // Weather conditions
+	Comment   string      `xml:"comment,omitempty" json:"comment,omitempty"` // Forecast commentary, only when requested with show_comments=yes
 }
 
 // Weather report for a Local Forecast.
 type ForecastWeather struct {
 	Weather
-	Condition []ForecastCondition `xml:"hourly"` // Forcasted weather conditions
+	Condition []ForecastCondition `xml:"hourly" json:"hourly"` // Forcasted weather conditions
 }
 
 // Weather report for a Marine Forecast.
 type MarineWeather struct {
 	Weather
-	Condition []MarineCondition `xml:"hourly"`          // Forcasted weather conditions
-	Tide      []Tide            `xml:"tides>tide_data"` // Tide information
+	Condition []MarineCondition `xml:"hourly" json:"hourly"`         // Forcasted weather conditions
+	Tide      []Tide            `xml:"tides>tide_data" json:"tides"` // Tide information
 }
 
 // weather report for a Ski Forecast
 type SkiWeather struct {
 	Weather
-	ChanceSnow uint           `xml:"chanceofsnow"`     // %   Chance of snow
-	TotalSnow  float64        `xml:"totalSnowfall_cm"` // cm  Total snowfall amount
-	Top        TempRange      `xml:"top"`              //     Temperature range at top
-	Mid        TempRange      `xml:"mid"`              //     Temperature range at middle
-	Bottom     TempRange      `xml:"bottom"`           //     Temperature range at bottom
-	Condition  []SkiCondition `xml:"hourly"`           //     Forcasted weather conditions
+	ChanceSnow uint           `xml:"chanceofsnow" json:"chance_snow"`       // %   Chance of snow
+	TotalSnow  float64        `xml:"totalSnowfall_cm" json:"total_snow_cm"` // cm  Total snowfall amount
+	Top        TempRange      `xml:"top" json:"top"`                        //     Temperature range at top
+	Mid        TempRange      `xml:"mid" json:"mid"`                        //     Temperature range at middle
+	Bottom     TempRange      `xml:"bottom" json:"bottom"`                  //     Temperature range at bottom
+	Condition  []SkiCondition `xml:"hourly" json:"hourly"`                  //     Forcasted weather conditions
 }
 
 // A tide entry in a Marine Forecast or Record.
 type Tide struct {
-	Time   Time12  `xml:"tideTime"9`     //    Local time of tide
-	Height float64 `xml:"tideHeight_mt"` // m  Tide height
-	Type   string  `xml:"tide_type"`     //    High, Low, Normal
+	Time   Time12    `xml:"tideTime" json:"time"`          //    Local time of tide
+	Height float64   `xml:"tideHeight_mt" json:"height_m"` // m  Tide height
+	Type   string    `xml:"tide_type" json:"type"`         //    High, Low, Normal
+	At     time.Time `xml:"-" json:"-"`                    //    Absolute time of tide, only once set by LocalizeTimes
 }
 
 // Astronomical events for a day.
 type Astronomy struct {
-	Moonrise Time12 `xml:"moonrise"` // Local time of moonrise
-	Moonset  Time12 `xml:"moonset"`  // Local time of moonset
-	Sunrise  Time12 `xml:"sunrise"`  // Local time of sunrise
-	Sunset   Time12 `xml:"sunset"`   // Local time of sunset
+	Moonrise         Time12 `xml:"moonrise" json:"moonrise"`                   //    Local time of moonrise
+	Moonset          Time12 `xml:"moonset" json:"moonset"`                     //    Local time of moonset
+	Sunrise          Time12 `xml:"sunrise" json:"sunrise"`                     //    Local time of sunrise
+	Sunset           Time12 `xml:"sunset" json:"sunset"`                       //    Local time of sunset
+	MoonPhase        string `xml:"moon_phase" json:"moon_phase"`               //    Phase of the moon, e.g. Waxing Gibbous
+	MoonIllumination uint   `xml:"moon_illumination" json:"moon_illumination"` // %  Illuminated fraction of the moon
+
+	// Absolute times of the events, only once set by LocalizeTimes,
+	// and left zero for "No event".
+	MoonriseAt time.Time `xml:"-" json:"-"`
+	MoonsetAt  time.Time `xml:"-" json:"-"`
+	SunriseAt  time.Time `xml:"-" json:"-"`
+	SunsetAt   time.Time `xml:"-" json:"-"`
+}
+
+// The time from sunrise to sunset, ok being false if there is no sunrise or sunset, as in polar day or night.
+// A sunset earlier in the day than sunrise is taken to be after midnight.
+func (a *Astronomy) Daylight() (d time.Duration, ok bool) {
+	if !a.Sunrise.Valid() || !a.Sunset.Valid() {
+		return 0, false
+	}
+
+	d = time.Duration(a.Sunset - a.Sunrise)
+	if d < 0 {
+		d += 24 * time.Hour
+	}
+	return d, true
+}
+
+// Whether the sun does not set on the day d at latitude lat, which is usually that of the area.
+// With neither sunrise nor sunset this is taken to be so in the summer half of the year for the hemisphere,
+// April to September in the north.
+func (a *Astronomy) IsPolarDay(d Date, lat float64) bool {
+	switch {
+	case a.Sunrise.Valid() && a.Sunset.Valid():
+		return false
+	case a.Sunrise.Valid():
+		return true
+	case a.Sunset.Valid():
+		return false
+	}
+	return northernSummer(d) == (lat >= 0)
+}
+
+// Whether the sun does not rise on the day d at latitude lat, which is usually that of the area.
+// With neither sunrise nor sunset this is taken to be so in the winter half of the year for the hemisphere,
+// October to March in the north.
+func (a *Astronomy) IsPolarNight(d Date, lat float64) bool {
+	switch {
+	case a.Sunrise.Valid() && a.Sunset.Valid():
+		return false
+	case a.Sunrise.Valid():
+		return false
+	case a.Sunset.Valid():
+		return true
+	}
+	return northernSummer(d) != (lat >= 0)
+}
+
+func northernSummer(d Date) bool {
+	m := time.Time(d).Month()
+	return m >= time.April && m <= time.September
 }
 
 // Weather conditions at a particular elevation band.
 type LevelCond struct {
-	Temp              int    `xml:"tempC"`             // °C     Temperature
-	TempF             int    `xml:"tempF"`             // °F     Temperature
-	WindSpeed         uint   `xml:"windspeedKmph"`     // km/hr  Wind speed
-	WindSpeedKnots    uint   `xml:"windspeedKnots"`    // knots  Wind speed
-	WindSpeedMeterSec uint   `xml:"windspeedMeterSec"` // m/s    Wind speed
-	WindSpeedMiles    uint   `xml:"windspeedMiles"`    // mi/hr  Wind speed
-	WindDir           uint   `xml:"winddirDegree"`     // °EoN   Wind direction
-	WindDirCompass    string `xml:"winddir16Point"`    //        Wind direction 16-point compass
-	WeatherCode       uint   `xml:"weatherCode"`       //        Weather condition code <https://developer.worldweatheronline.com/api/docs/weather-icons.aspx>
-	WeatherDesc       string `xml:"weatherDesc"`       //        Weather condition description
-	WeatherIconUrl    string `xml:"weatherIconUrl"`    //        URL to weather icon
+	Temp              int    `xml:"tempC" json:"temp_c"`                           // °C     Temperature
+	TempF             int    `xml:"tempF" json:"temp_f"`                           // °F     Temperature
+	WindSpeed         uint   `xml:"windspeedKmph" json:"wind_speed_kmph"`          // km/hr  Wind speed
+	WindSpeedKnots    uint   `xml:"windspeedKnots" json:"wind_speed_knots"`        // knots  Wind speed
+	WindSpeedMeterSec uint   `xml:"windspeedMeterSec" json:"wind_speed_meter_sec"` // m/s    Wind speed
+	WindSpeedMiles    uint   `xml:"windspeedMiles" json:"wind_speed_miles"`        // mi/hr  Wind speed
+	WindDir           uint   `xml:"winddirDegree" json:"wind_dir"`                 // °EoN   Wind direction
+	WindDirCompass    string `xml:"winddir16Point" json:"wind_dir_compass"`        //        Wind direction 16-point compass
+	WeatherCode       uint   `xml:"weatherCode" json:"weather_code"`               //        Weather condition code <https://developer.worldweatheronline.com/api/docs/weather-icons.aspx>
+	WeatherDesc       string `xml:"weatherDesc" json:"weather_desc"`               //        Weather condition description
+	WeatherIconUrl    string `xml:"weatherIconUrl" json:"weather_icon_url"`        //        URL to weather icon
 }
 
 // Weather conditions for a Ski Forecast.
 type SkiCondition struct {
 	ForecastChances
-	Top             LevelCond `xml:"top"`             //       Temperature range at top
-	Mid             LevelCond `xml:"mid"`             //       Temperature range at middle
-	Bottom          LevelCond `xml:"bottom"`          //       Temperature range at bottom
-	CloudCover      uint      `xml:"cloudcover"`      // %     Cloud cover amount
-	Visibility      uint      `xml:"visibility"`      // km    Visibility
-	VisibilityMiles uint      `xml:"visibilityMiles"` // mi    Visibility
-	Pressure        uint      `xml:"pressure"`        // mbar  Atmospheric pressure
-	PressureInches  uint      `xml:"pressureInches"`  // in    Atmospheric pressure
-	Snowfall        float64   `xml:"snowfall_cm"`     // cm    Snowfall
-	FreezeLevel     uint      `xml:"freezeLevel"`     // m     Freeze elevation
-	Humidity        uint      `xml:"humidity"`        // %     Humidity
-	Precip          float64   `xml:"precipMM"`        // mm    Precipitation
-	PrecipInches    float64   `xml:"precipInches"`    // in    Precipitation
+	Top             LevelCond `xml:"top" json:"top"`                          //       Temperature range at top
+	Mid             LevelCond `xml:"mid" json:"mid"`                          //       Temperature range at middle
+	Bottom          LevelCond `xml:"bottom" json:"bottom"`                    //       Temperature range at bottom
+	CloudCover      uint      `xml:"cloudcover" json:"cloud_cover"`           // %     Cloud cover amount
+	Visibility      uint      `xml:"visibility" json:"visibility_km"`         // km    Visibility
+	VisibilityMiles uint      `xml:"visibilityMiles" json:"visibility_miles"` // mi    Visibility
+	Pressure        uint      `xml:"pressure" json:"pressure_mb"`             // mbar  Atmospheric pressure
+	PressureInches  uint      `xml:"pressureInches" json:"pressure_inches"`   // in    Atmospheric pressure
+	Snowfall        float64   `xml:"snowfall_cm" json:"snowfall_cm"`          // cm    Snowfall
+	FreezeLevel     uint      `xml:"freezeLevel" json:"freeze_level_m"`       // m     Freeze elevation
+	Humidity        uint      `xml:"humidity" json:"humidity"`                // %     Humidity
+	Precip          float64   `xml:"precipMM" json:"precip_mm"`               // mm    Precipitation
+	PrecipInches    float64   `xml:"precipInches" json:"precip_inches"`       // in    Precipitation
 }
 
 // Weather conditions common to most reports.
+//
+// Numeric fields are zero when not given as well as when zero,
+// which matters for those that may truly be zero, such as temperatures, wind direction, and precipitation,
+// so Has tells the two apart.
 type Condition struct {
-	Time              TimeHMM `xml:"time"`              //        Local time (Duration after start of day)
-	CloudCover        uint    `xml:"cloudcover"`        // %      Cloud cover amount
-	DewPoint          int     `xml:"DewPointC"`         // °C     Dew point temperature
-	DewPointF         int     `xml:"DewPointF"`         // °F     Dew point temperature
-	FeelsLike         int     `xml:"FeelsLikeC"`        // °C     Feels like temperature
-	FeelsLikeF        int     `xml:"FeelsLikeF"`        // °F     Feels like temperature
-	HeatIndex         int     `xml:"HeatIndexC"`        // °C     Heat index temperature
-	HeatIndexF        int     `xml:"HeatIndexF"`        // °F     Heat index temperature
-	Humidity          uint    `xml:"humidity"`          // %      Humidity
-	Precip            float64 `xml:"precipMM"`          // mm     Precipitation
-	PrecipInches      float64 `xml:"precipInches"`      // in     Precipitation
-	Pressure          uint    `xml:"pressure"`          // mbar   Atmospheric pressure
-	PressureInches    uint    `xml:"pressureInches"`    // in     Atmospheric pressure
-	Temp              int     `xml:"tempC"`             // °C     Temperature
-	TempF             int     `xml:"tempF"`             // °F     Temperature
-	Visibility        uint    `xml:"visibility"`        // km     Visibility
-	VisibilityMiles   uint    `xml:"visibilityMiles"`   // mi     Visibility
-	WeatherCode       uint    `xml:"weatherCode"`       //        Weather condition code <https://developer.worldweatheronline.com/api/docs/weather-icons.aspx>
-	WeatherDesc       string  `xml:"weatherDesc"`       //        Weather condition description
-	WeatherIconUrl    string  `xml:"weatherIconUrl"`    //        URL to weather icon
-	WindChill         int     `xml:"WindChillC"`        // °C     Wind chill temperature
-	WindChillF        int     `xml:"WindChillF"`        // °F     Wind chill temperature
-	WindDir           uint    `xml:"winddirDegree"`     // °EoN   Wind direction
-	WindDirCompass    string  `xml:"winddir16Point"`    //        Wind direction 16-point compass
-	WindGust          uint    `xml:"WindGustKmph"`      // km/hr  Wind gust
-	WindGustMiles     uint    `xml:"WindGustMiles"`     // mi/hr  Wind gust
-	WindSpeed         uint    `xml:"windspeedKmph"`     // km/hr  Wind speed
-	WindSpeedKnots    uint    `xml:"windspeedKnots"`    // knots  Wind speed
-	WindSpeedMeterSec uint    `xml:"windspeedMeterSec"` // m/s    Wind speed
-	WindSpeedMiles    uint    `xml:"windspeedMiles"`    // mi/hr  Wind speed
+	Time              TimeHMM     `xml:"time" json:"time"`                              //        Local time (Duration after start of day)
+	CloudCover        uint        `xml:"cloudcover" json:"cloud_cover"`                 // %      Cloud cover amount
+	DewPoint          int         `xml:"DewPointC" json:"dew_point_c"`                  // °C     Dew point temperature, only when requested with extra=dewPoint
+	DewPointF         int         `xml:"DewPointF" json:"dew_point_f"`                  // °F     Dew point temperature, only when requested with extra=dewPoint
+	FeelsLike         int         `xml:"FeelsLikeC" json:"feels_like_c"`                // °C     Feels like temperature
+	FeelsLikeF        int         `xml:"FeelsLikeF" json:"feels_like_f"`                // °F     Feels like temperature
+	HeatIndex         int         `xml:"HeatIndexC" json:"heat_index_c"`                // °C     Heat index temperature, only when requested with extra=heatIndex
+	HeatIndexF        int         `xml:"HeatIndexF" json:"heat_index_f"`                // °F     Heat index temperature, only when requested with extra=heatIndex
+	Humidity          uint        `xml:"humidity" json:"humidity"`                      // %      Humidity
+	Precip            float64     `xml:"precipMM" json:"precip_mm"`                     // mm     Precipitation
+	PrecipInches      float64     `xml:"precipInches" json:"precip_inches"`             // in     Precipitation
+	Pressure          uint        `xml:"pressure" json:"pressure_mb"`                   // mbar   Atmospheric pressure
+	PressureInches    uint        `xml:"pressureInches" json:"pressure_inches"`         // in     Atmospheric pressure
+	Temp              int         `xml:"tempC" json:"temp_c"`                           // °C     Temperature
+	TempF             int         `xml:"tempF" json:"temp_f"`                           // °F     Temperature
+	Visibility        uint        `xml:"visibility" json:"visibility_km"`               // km     Visibility
+	VisibilityMiles   uint        `xml:"visibilityMiles" json:"visibility_miles"`       // mi     Visibility
+	WeatherCode       uint        `xml:"weatherCode" json:"weather_code"`               //        Weather condition code <https://developer.worldweatheronline.com/api/docs/weather-icons.aspx>
+	WeatherDesc       string      `xml:"weatherDesc" json:"weather_desc"`               //        Weather condition description
+	WeatherIconUrl    string      `xml:"weatherIconUrl" json:"weather_icon_url"`        //        URL to weather icon
+	WindChill         int         `xml:"WindChillC" json:"wind_chill_c"`                // °C     Wind chill temperature, only when requested with extra=windChill
+	WindChillF        int         `xml:"WindChillF" json:"wind_chill_f"`                // °F     Wind chill temperature, only when requested with extra=windChill
+	WindDir           uint        `xml:"winddirDegree" json:"wind_dir"`                 // °EoN   Wind direction
+	WindDirCompass    string      `xml:"winddir16Point" json:"wind_dir_compass"`        //        Wind direction 16-point compass
+	WindGust          uint        `xml:"WindGustKmph" json:"wind_gust_kmph"`            // km/hr  Wind gust, only when requested with extra=windGust
+	WindGustMiles     uint        `xml:"WindGustMiles" json:"wind_gust_miles"`          // mi/hr  Wind gust, only when requested with extra=windGust
+	WindSpeed         uint        `xml:"windspeedKmph" json:"wind_speed_kmph"`          // km/hr  Wind speed
+	WindSpeedKnots    uint        `xml:"windspeedKnots" json:"wind_speed_knots"`        // knots  Wind speed
+	WindSpeedMeterSec uint        `xml:"windspeedMeterSec" json:"wind_speed_meter_sec"` // m/s    Wind speed
+	WindSpeedMiles    uint        `xml:"windspeedMiles" json:"wind_speed_miles"`        // mi/hr  Wind speed
+	AirQuality        *AirQuality `xml:"air_quality" json:"air_quality,omitempty"`      //        Air quality, only when requested with aqi=yes
+	IsDayTime         YesNo       `xml:"isdaytime" json:"is_day_time"`                  //        Whether it is daytime, only when requested with extra=isDayTime
+	UTCDate           Date        `xml:"UTCdate" json:"utc_date"`                       //        Date in UTC, only when requested with extra=utcDateTime
+	UTCTime           TimeHMM     `xml:"UTCtime" json:"utc_time"`                       //        Time in UTC, only when requested with extra=utcDateTime
+	given             elementSet  `xml:"-" json:"-"`                                    //        Names of the elements given in the response, as used by Has
+	At                time.Time   `xml:"-" json:"-"`                                    //        Absolute time of the conditions, only once set by LocalizeTimes
+}
+
+// The time of the conditions, or of the observation for current conditions,
+// from UTCDate and UTCTime. This is the zero time unless requested with extra=utcDateTime.
+func (c *Condition) UTC() time.Time {
+	if time.Time(c.UTCDate).IsZero() {
+		return time.Time{}
+	}
+	return c.UTCTime.On(c.UTCDate, nil)
+}
+
+// Air quality, included in conditions when requested.
+type AirQuality struct {
+	CO           float64 `xml:"co" json:"co"`                         // μg/m³  Carbon monoxide
+	NO2          float64 `xml:"no2" json:"no2"`                       // μg/m³  Nitrogen dioxide
+	O3           float64 `xml:"o3" json:"o3"`                         // μg/m³  Ozone
+	SO2          float64 `xml:"so2" json:"so2"`                       // μg/m³  Sulphur dioxide
+	PM25         float64 `xml:"pm2_5" json:"pm2_5"`                   // μg/m³  Particulate matter under 2.5 microns
+	PM10         float64 `xml:"pm10" json:"pm10"`                     // μg/m³  Particulate matter under 10 microns
+	USEPAIndex   uint    `xml:"us-epa-index" json:"us_epa_index"`     //        US EPA index (1-6)
+	GBDEFRAIndex uint    `xml:"gb-defra-index" json:"gb_defra_index"` //        UK DEFRA index (1-10)
 }
 
 // Current weather conditions in a Local Forecast.
 type CurrentCondition struct {
 	Condition
-	TempF int    `xml:"temp_F"`           // °F  Temperature
-	Temp  int    `xml:"temp_C"`           // °C  Temperature
-	Time  Time12 `xml:"observation_time"` //     Time of the observation
+	TempF int    `xml:"temp_F" json:"temp_f"`         // °F  Temperature
+	Temp  int    `xml:"temp_C" json:"temp_c"`         // °C  Temperature
+	Time  Time12 `xml:"observation_time" json:"time"` //     Time of the observation in UTC
 }
 
 // Chances of various conditions in a Local Forecast.
 type ForecastChances struct {
-	ChanceFog      uint `xml:"chanceoffog"`      // %  Chance of fog
-	ChanceFrost    uint `xml:"chanceoffrost"`    // %  Chance of front
-	ChanceOvercast uint `xml:"chanceofovercast"` // %  Chance of being cloudy
-	ChanceRain     uint `xml:"chanceofrain"`     // %  Chance of rain
-	ChanceSnow     uint `xml:"chanceofsnow"`     // %  Chance of snow
-	ChanceHighTemp uint `xml:"chanceofhightemp"` // %  Chance of high temperatures FIXME not in docs
-	ChanceDry      uint `xml:"chanceofremdry"`   // %  Chance of remaining dry FIXME not in docs
-	ChanceSunshine uint `xml:"chanceofsunshine"` // %  Chance of being sunny
-	ChanceThunder  uint `xml:"chanceofthunder"`  // %  Chance of thunder and/or lightning
-	ChanceWindy    uint `xml:"chanceofwindy"`    // %  Chance of being windy
+	ChanceFog      uint `xml:"chanceoffog" json:"chance_fog"`            // %  Chance of fog
+	ChanceFrost    uint `xml:"chanceoffrost" json:"chance_frost"`        // %  Chance of front
+	ChanceOvercast uint `xml:"chanceofovercast" json:"chance_overcast"`  // %  Chance of being cloudy
+	ChanceRain     uint `xml:"chanceofrain" json:"chance_rain"`          // %  Chance of rain
+	ChanceSnow     uint `xml:"chanceofsnow" json:"chance_snow"`          // %  Chance of snow
+	ChanceHighTemp uint `xml:"chanceofhightemp" json:"chance_high_temp"` // %  Chance of high temperatures FIXME not in docs
+	ChanceDry      uint `xml:"chanceofremdry" json:"chance_dry"`         // %  Chance of remaining dry FIXME not in docs
+	ChanceSunshine uint `xml:"chanceofsunshine" json:"chance_sunshine"`  // %  Chance of being sunny
+	ChanceThunder  uint `xml:"chanceofthunder" json:"chance_thunder"`    // %  Chance of thunder and/or lightning
+	ChanceWindy    uint `xml:"chanceofwindy" json:"chance_windy"`        // %  Chance of being windy
 }
 
 // Conditions in the n-hourly Local Forecast.
@@ -241,90 +523,187 @@ type ForecastCondition struct {
 // Conditions in the n-hourly Marine Forecast.
 type MarineCondition struct {
 	Condition
-	SigHeight       float64 `xml:"sigHeight_m"`      // m    Significant wave height
-	SwellHeight     float64 `xml:"swellHeight_m"`    // m    Swell wave height
-	SwellHeight_ft  float64 `xml:"swellHeight_ft"`   // ft   Swell wave height FIXME docs say swell_Height_ft
-	SwellDir        uint    `xml:"swellDir"`         // °EoN Swell direction
-	SwellDirCompass string  `xml:"swellDir16Point"`  //      Swell compass direction
-	SwellPeriod     float64 `xml:"swellPeriod_secs"` // sec  Swell period
-	WaterTemp       int     `xml:"waterTemp_C"`      // °C   Water temperature
-	WaterTemp_F     int     `xml:"waterTemp_F"`      // °F   Water temperature
+	SigHeight       float64 `xml:"sigHeight_m" json:"sig_height_m"`           // m    Significant wave height
+	SwellHeight     float64 `xml:"swellHeight_m" json:"swell_height_m"`       // m    Swell wave height
+	SwellHeight_ft  float64 `xml:"swellHeight_ft" json:"swell_height_ft"`     // ft   Swell wave height, swellHeight_ft in responses though the docs say swell_Height_ft
+	SwellDir        uint    `xml:"swellDir" json:"swell_dir"`                 // °EoN Swell direction
+	SwellDirCompass string  `xml:"swellDir16Point" json:"swell_dir_compass"`  //      Swell compass direction
+	SwellPeriod     float64 `xml:"swellPeriod_secs" json:"swell_period_secs"` // sec  Swell period
+	WaterTemp       int     `xml:"waterTemp_C" json:"water_temp_c"`           // °C   Water temperature
+	WaterTemp_F     int     `xml:"waterTemp_F" json:"water_temp_f"`           // °F   Water temperature
 }
 
 // Climate averages in a Local Forecast.
 type ClimateAverage struct {
-	Index                uint    `xml:"index"`                   //        Month index Integer: 1-12
-	Name                 string  `xml:"name"`                    //        The name of the month
-	MinTemp              float64 `xml:"avgMinTemp"`              // °C     Average minimum temperature
-	MinTemp_F            float64 `xml:"avgMinTemp_F"`            // °F     Average minimum temperature
-	MaxTemp              float64 `xml:"avgMaxTemp"`              // °C     Average maximum temperature
-	MaxTemp_F            float64 `xml:"avgMaxTemp_F"`            // °F     Average maximum temperature
-	AbsMinTemp           float64 `xml:"absMinTemp"`              // °C     Absolute minimum temperature
-	AbsMinTemp_F         float64 `xml:"absMinTemp_F"`            // °F     Absolute minimum temperature
-	AbsMaxTemp           float64 `xml:"absMaxTemp"`              // °C     Absolute maximum temperature
-	AbsMaxTemp_F         float64 `xml:"absMaxTemp_F"`            // °F     Absolute maximum temperature
-	Temp                 float64 `xml:"avgTemp"`                 // °C     Average temperature
-	Temp_F               float64 `xml:"avgTemp_F"`               // °F     Average temperature
-	MaxWindSpeed         float64 `xml:"maxWindSpeed_kmph"`       // km/hr  Maximum wind speed FIXME average or absolute?
-	MaxWindSpeed_mph     float64 `xml:"maxWindSpeed_mph"`        // mi/hr  Maximum wind speed
-	MaxWindSpeed_knots   float64 `xml:"maxWindSpeed_knots"`      // knots  Maximum wind speed
-	MaxWindSpeed_ms      float64 `xml:"maxWindSpeed_ms"`         // m/s    Maximum wind speed
-	WindSpeed            float64 `xml:"avgWindSpeed_kmph"`       // km/hr  Average wind speed
-	WindSpeed_miles      float64 `xml:"avgWindSpeed_miles"`      // mi/hr  Average wind speed
-	WindSpeed_knots      float64 `xml:"avgWindSpeed_knots"`      // knots  Average wind speed
-	WindSpeed_ms         float64 `xml:"avgWindSpeed_ms"`         // m/s    Average wind speed
-	WindGust             float64 `xml:"avgWindGust_kmph"`        // km/hr  Average wind gust
-	WindGust_miles       float64 `xml:"avgWindGust_miles"`       // mi/hr  Average wind gust
-	WindGust_knots       float64 `xml:"avgWindGust_knots"`       // knots  Average wind gust
-	WindGust_ms          float64 `xml:"avgWindGust_ms"`          // m/s    Average wind gust
-	DailyRainfall        float64 `xml:"avgDailyRainfall"`        // mm     Average daily rainfall
-	DailyRainfall_inch   float64 `xml:"avgDailyRainfall_inch"`   // in     Average daily rainfall
-	MonthlyRainfall      float64 `xml:"avgMonthlyRainfall"`      // mm     Average monthly rainfall
-	MonthlyRainfall_inch float64 `xml:"avgMonthlyRainfall_inch"` // in     Average monthly rainfall
-	Humidity             float64 `xml:"avgHumidity"`             // %      Average humidity
-	Cloud                float64 `xml:"avgCloud"`                // %      Average cloud cover
-	Visibility           float64 `xml:"avgVis_km"`               // km     Average visibility
-	Visibility_miles     float64 `xml:"avgVis_miles"`            // mi     Average visibility
-	Pressure             float64 `xml:"avgPressure_mb"`          // mbar   Average pressure
-	Pressure_inch        float64 `xml:"avgPressure_inch"`        // in     Average pressure
-	DryDays              uint    `xml:"avgDryDays"`              //        Average number of dry days
-	RainDays             uint    `xml:"avgRainDays"`             //        Average number of rain days
-	SnowDays             uint    `xml:"avgSnowDays"`             //        Average number of snow days
-	FogDays              uint    `xml:"avgFogDays"`              //        Average number of foggy days
-	ThunderDays          uint    `xml:"avgThunderDays"`          //        Average number of thunder days
-	UVIndex              uint    `xml:"avgUVIndex"`              //        Average UV Index
-	SunHour              float64 `xml:"avgSunHour"`              // hr/day Average Sun
+	Index                uint    `xml:"index" json:"index"`                                   //        Month index Integer: 1-12
+	Name                 string  `xml:"name" json:"name"`                                     //        The name of the month
+	MinTemp              float64 `xml:"avgMinTemp" json:"min_temp_c"`                         // °C     Average minimum temperature
+	MinTemp_F            float64 `xml:"avgMinTemp_F" json:"min_temp_f"`                       // °F     Average minimum temperature
+	MaxTemp              float64 `xml:"avgMaxTemp" json:"max_temp_c"`                         // °C     Average maximum temperature
+	MaxTemp_F            float64 `xml:"avgMaxTemp_F" json:"max_temp_f"`                       // °F     Average maximum temperature
+	AbsMinTemp           float64 `xml:"absMinTemp" json:"abs_min_temp_c"`                     // °C     Absolute minimum temperature
+	AbsMinTemp_F         float64 `xml:"absMinTemp_F" json:"abs_min_temp_f"`                   // °F     Absolute minimum temperature
+	AbsMaxTemp           float64 `xml:"absMaxTemp" json:"abs_max_temp_c"`                     // °C     Absolute maximum temperature
+	AbsMaxTemp_F         float64 `xml:"absMaxTemp_F" json:"abs_max_temp_f"`                   // °F     Absolute maximum temperature
+	Temp                 float64 `xml:"avgTemp" json:"temp_c"`                                // °C     Average temperature
+	Temp_F               float64 `xml:"avgTemp_F" json:"temp_f"`                              // °F     Average temperature
+	MaxWindSpeed         float64 `xml:"maxWindSpeed_kmph" json:"max_wind_speed_kmph"`         // km/hr  Maximum wind speed FIXME average or absolute?
+	MaxWindSpeed_mph     float64 `xml:"maxWindSpeed_mph" json:"max_wind_speed_mph"`           // mi/hr  Maximum wind speed
+	MaxWindSpeed_knots   float64 `xml:"maxWindSpeed_knots" json:"max_wind_speed_knots"`       // knots  Maximum wind speed
+	MaxWindSpeed_ms      float64 `xml:"maxWindSpeed_ms" json:"max_wind_speed_ms"`             // m/s    Maximum wind speed
+	WindSpeed            float64 `xml:"avgWindSpeed_kmph" json:"wind_speed_kmph"`             // km/hr  Average wind speed
+	WindSpeed_miles      float64 `xml:"avgWindSpeed_miles" json:"wind_speed_miles"`           // mi/hr  Average wind speed
+	WindSpeed_knots      float64 `xml:"avgWindSpeed_knots" json:"wind_speed_knots"`           // knots  Average wind speed
+	WindSpeed_ms         float64 `xml:"avgWindSpeed_ms" json:"wind_speed_ms"`                 // m/s    Average wind speed
+	WindGust             float64 `xml:"avgWindGust_kmph" json:"wind_gust_kmph"`               // km/hr  Average wind gust
+	WindGust_miles       float64 `xml:"avgWindGust_miles" json:"wind_gust_miles"`             // mi/hr  Average wind gust
+	WindGust_knots       float64 `xml:"avgWindGust_knots" json:"wind_gust_knots"`             // knots  Average wind gust
+	WindGust_ms          float64 `xml:"avgWindGust_ms" json:"wind_gust_ms"`                   // m/s    Average wind gust
+	DailyRainfall        float64 `xml:"avgDailyRainfall" json:"daily_rainfall_mm"`            // mm     Average daily rainfall
+	DailyRainfall_inch   float64 `xml:"avgDailyRainfall_inch" json:"daily_rainfall_inch"`     // in     Average daily rainfall
+	MonthlyRainfall      float64 `xml:"avgMonthlyRainfall" json:"monthly_rainfall_mm"`        // mm     Average monthly rainfall
+	MonthlyRainfall_inch float64 `xml:"avgMonthlyRainfall_inch" json:"monthly_rainfall_inch"` // in     Average monthly rainfall
+	Humidity             float64 `xml:"avgHumidity" json:"humidity"`                          // %      Average humidity
+	Cloud                float64 `xml:"avgCloud" json:"cloud"`                                // %      Average cloud cover
+	Visibility           float64 `xml:"avgVis_km" json:"visibility_km"`                       // km     Average visibility
+	Visibility_miles     float64 `xml:"avgVis_miles" json:"visibility_miles"`                 // mi     Average visibility
+	Pressure             float64 `xml:"avgPressure_mb" json:"pressure_mb"`                    // mbar   Average pressure
+	Pressure_inch        float64 `xml:"avgPressure_inch" json:"pressure_inch"`                // in     Average pressure
+	DryDays              uint    `xml:"avgDryDays" json:"dry_days"`                           //        Average number of dry days
+	RainDays             uint    `xml:"avgRainDays" json:"rain_days"`                         //        Average number of rain days
+	SnowDays             uint    `xml:"avgSnowDays" json:"snow_days"`                         //        Average number of snow days
+	FogDays              uint    `xml:"avgFogDays" json:"fog_days"`                           //        Average number of foggy days
+	ThunderDays          uint    `xml:"avgThunderDays" json:"thunder_days"`                   //        Average number of thunder days
+	UVIndex              uint    `xml:"avgUVIndex" json:"uv_index"`                           //        Average UV Index
+	SunHour              float64 `xml:"avgSunHour" json:"sun_hour"`                           // hr/day Average Sun
+}
+
+// A severe weather alert in a Local Forecast.
+type Alert struct {
+	Headline    string   `xml:"headline" json:"headline"`       // Summary of the alert
+	Type        string   `xml:"msgtype" json:"type"`            // Alert, Update, or Cancel
+	Severity    string   `xml:"severity" json:"severity"`       // Extreme, Severe, Moderate, Minor, or Unknown
+	Urgency     string   `xml:"urgency" json:"urgency"`         // Immediate, Expected, Future, Past, or Unknown
+	Areas       string   `xml:"areas" json:"areas"`             // Areas affected
+	Category    string   `xml:"category" json:"category"`       // Category of event, e.g. Met
+	Certainty   string   `xml:"certainty" json:"certainty"`     // Observed, Likely, Possible, Unlikely, or Unknown
+	Event       string   `xml:"event" json:"event"`             // Type of event, e.g. Flood Warning
+	Note        string   `xml:"note" json:"note"`               // Additional notes
+	Effective   DateTime `xml:"effective" json:"effective"`     // Time the alert takes effect
+	Expires     DateTime `xml:"expires" json:"expires"`         // Time the alert expires, zero if not given
+	Description string   `xml:"desc" json:"description"`        // Description of the event
+	Instruction string   `xml:"instruction" json:"instruction"` // Recommended action
 }
 
 // Timezone Offset Information
 type Zone struct {
-	Offset float64 `xml:"utcOffset"` // hr  Offset from UTC including fractional hours
+	Offset    float64 `xml:"utcOffset" json:"utc_offset"`           // hr  Offset from UTC including fractional hours
+	LocalTime string  `xml:"localtime" json:"local_time,omitempty"` //     Local time at the time of the request, as yyyy-mm-dd HH:MM, only in time zone reports
+	ZoneName  string  `xml:"zone" json:"zone_name,omitempty"`       //     IANA name of the time zone, e.g. Europe/London, only in time zone reports
+}
+
+// The local time at the time of the request, in the zone's Location,
+// and whether it was given.
+func (z *Zone) Time() (time.Time, bool) {
+	if z == nil || z.LocalTime == "" {
+		return time.Time{}, false
+	}
+
+	t, err := time.ParseInLocation("2006-01-02 15:04", z.LocalTime, z.Location())
+	return t, err == nil
+}
+
+// Whether daylight saving time is in effect at the zone's LocalTime, or now if that is not given,
+// and whether that is known.
+// The API gives no flag for this, so it is found from the time zone database for ZoneName,
+// and is unknown if there is no ZoneName or it is not in the database.
+func (z *Zone) IsDST() (dst, ok bool) {
+	if z == nil || z.ZoneName == "" {
+		return false, false
+	}
+
+	loc, err := time.LoadLocation(z.ZoneName)
+	if err != nil {
+		return false, false
+	}
+
+	t, given := z.Time()
+	if !given {
+		t = time.Now()
+	}
+	return t.In(loc).IsDST(), true
+}
+
+// The fixed time zone for the offset, including fractional hours such as 5.75 for +05:45,
+// named for the offset, e.g. "UTC+05:45", or UTC if z is nil.
+func (z *Zone) Location() *time.Location {
+	if z == nil {
+		return time.UTC
+	}
+
+	secs := int(math.Round(z.Offset * 3600))
+	if secs == 0 {
+		return time.FixedZone("UTC", 0)
+	}
+
+	sign, abs := "+", secs
+	if secs < 0 {
+		sign, abs = "-", -secs
+	}
+	name := fmt.Sprintf("UTC%s%02d:%02d", sign, abs/3600, abs%3600/60)
+
+	return time.FixedZone(name, secs)
 }
 
 // A Local Weather Forecast
 type Local struct {
-	Area    Area              `xml:"nearest_area"`          // the nearest area to the query
-	Climate []ClimateAverage  `xml:"ClimateAverages>month"` // monthly climate averages
-	Current CurrentCondition  `xml:"current_condition"`     // current weather conditions
-	Request Request           `xml:"request"`               // details of the original request
-	Weather []ForecastWeather `xml:"weather"`               // forecasted weather conditions
-	Error   *string           `xml:"error>msg"`             // errors
+	XMLName   xml.Name          `xml:"data" json:"-"`                                 // the root element of the response
+	Area      Area              `xml:"nearest_area" json:"area"`                      // the nearest area to the query, only when requested with includelocation=yes
+	Climate   []ClimateAverage  `xml:"ClimateAverages>month" json:"climate_averages"` // monthly climate averages
+	Current   CurrentCondition  `xml:"current_condition" json:"current"`              // current weather conditions
+	Request   Request           `xml:"request" json:"request"`                        // details of the original request
+	Weather   []ForecastWeather `xml:"weather" json:"weather"`                        // forecasted weather conditions
+	Alerts    []Alert           `xml:"alerts>alert" json:"alerts,omitempty"`          // severe weather alerts, only when requested with alerts=yes
+	Error     *string           `xml:"error>msg" json:"error,omitempty"`              // errors
+	ErrorType *string           `xml:"error>type" json:"error_type,omitempty"`        // type of error, if given
+	Raw       []byte            `xml:"-" json:"-"`                                    // the response body, only if WWO.KeepRaw is set
+	FromCache bool              `xml:"-" json:"-"`                                    // whether the response came from the WWO Cache
+	CachedAt  time.Time         `xml:"-" json:"-"`                                    // when the response was cached, if it came from a Cache that records it
+	Options   map[string]string `xml:"-" json:"-"`                                    // the options sent with the request, including the location as q, but not the key or format
+}
+
+// The current conditions alone, as fetched by GetCurrent, with the nearest area they are for.
+type Current struct {
+	CurrentCondition
+	Area Area `xml:"nearest_area" json:"area"` // the nearest area to the query
 }
 
 // A Marine Weather Forecast
 type Marine struct {
-	Request Request         `xml:"request"`      // details of the original request
-	Area    Area            `xml:"nearest_area"` // the nearest area to the query
-	Weather []MarineWeather `xml:"weather"`      // the marine weather forecast
-	Error   *string         `xml:"error>msg"`    // errors
+	XMLName   xml.Name          `xml:"data" json:"-"`                          // the root element of the response
+	Request   Request           `xml:"request" json:"request"`                 // details of the original request
+	Area      Area              `xml:"nearest_area" json:"area"`               // the nearest area to the query, only when requested with includelocation=yes
+	Weather   []MarineWeather   `xml:"weather" json:"weather"`                 // the marine weather forecast
+	Error     *string           `xml:"error>msg" json:"error,omitempty"`       // errors
+	ErrorType *string           `xml:"error>type" json:"error_type,omitempty"` // type of error, if given
+	Raw       []byte            `xml:"-" json:"-"`                             // the response body, only if WWO.KeepRaw is set
+	FromCache bool              `xml:"-" json:"-"`                             // whether the response came from the WWO Cache
+	CachedAt  time.Time         `xml:"-" json:"-"`                             // when the response was cached, if it came from a Cache that records it
+	Options   map[string]string `xml:"-" json:"-"`                             // the options sent with the request, including the location as q, but not the key or format
 }
 
 // A Historical Local Weather Report
 type PastLocal struct {
-	Request Request   `xml:"request"`      // details of the original request
-	Area    Area      `xml:"nearest_area"` // the nearest area to the query
-	Weather []Weather `xml:"weather"`      // the historical weather report
-	Error   *string   `xml:"error>msg"`    // errors
+	XMLName   xml.Name          `xml:"data" json:"-"`                          // the root element of the response
+	Request   Request           `xml:"request" json:"request"`                 // details of the original request
+	Area      Area              `xml:"nearest_area" json:"area"`               // the nearest area to the query
+	Weather   []Weather         `xml:"weather" json:"weather"`                 // the historical weather report
+	Error     *string           `xml:"error>msg" json:"error,omitempty"`       // errors
+	ErrorType *string           `xml:"error>type" json:"error_type,omitempty"` // type of error, if given
+	Raw       []byte            `xml:"-" json:"-"`                             // the response body, only if WWO.KeepRaw is set
+	FromCache bool              `xml:"-" json:"-"`                             // whether the response came from the WWO Cache
+	CachedAt  time.Time         `xml:"-" json:"-"`                             // when the response was cached, if it came from a Cache that records it
+	Options   map[string]string `xml:"-" json:"-"`                             // the options sent with the request, including the location as q, but not the key or format
 }
 
 // A Historical Marine Weather Report
@@ -332,22 +711,40 @@ type PastMarine Marine // historical marine reports are very similar to marine f
 
 // A Ski Weather Forecast
 type Ski struct {
-	Request Request      `xml:"request"`      // details of the original request
-	Area    Area         `xml:"nearest_area"` // the nearest area to the query
-	Weather []SkiWeather `xml:"weather"`      // the ski weather forecast
-	Error   *string      `xml:"error>msg"`    // errors
+	XMLName   xml.Name          `xml:"data" json:"-"`                          // the root element of the response
+	Request   Request           `xml:"request" json:"request"`                 // details of the original request
+	Area      Area              `xml:"nearest_area" json:"area"`               // the nearest area to the query, only when requested with includelocation=yes
+	Weather   []SkiWeather      `xml:"weather" json:"weather"`                 // the ski weather forecast
+	Error     *string           `xml:"error>msg" json:"error,omitempty"`       // errors
+	ErrorType *string           `xml:"error>type" json:"error_type,omitempty"` // type of error, if given
+	Raw       []byte            `xml:"-" json:"-"`                             // the response body, only if WWO.KeepRaw is set
+	FromCache bool              `xml:"-" json:"-"`                             // whether the response came from the WWO Cache
+	CachedAt  time.Time         `xml:"-" json:"-"`                             // when the response was cached, if it came from a Cache that records it
+	Options   map[string]string `xml:"-" json:"-"`                             // the options sent with the request, including the location as q, but not the key or format
 }
 
 // A Timezone Report
 type TimeZone struct {
-	Request Request `xml:"request"`      // details of the original request
-	Area    Area    `xml:"nearest_area"` // the nearest area to the query
-	Zone    Zone    `xml:"time_zone"`    // the time zone data for the nearest area
-	Error   *string `xml:"error>msg"`    // errors
+	XMLName   xml.Name          `xml:"data" json:"-"`                          // the root element of the response
+	Request   Request           `xml:"request" json:"request"`                 // details of the original request
+	Area      Area              `xml:"nearest_area" json:"area"`               // the nearest area to the query
+	Zone      Zone              `xml:"time_zone" json:"zone"`                  // the time zone data for the nearest area
+	Error     *string           `xml:"error>msg" json:"error,omitempty"`       // errors
+	ErrorType *string           `xml:"error>type" json:"error_type,omitempty"` // type of error, if given
+	Raw       []byte            `xml:"-" json:"-"`                             // the response body, only if WWO.KeepRaw is set
+	FromCache bool              `xml:"-" json:"-"`                             // whether the response came from the WWO Cache
+	CachedAt  time.Time         `xml:"-" json:"-"`                             // when the response was cached, if it came from a Cache that records it
+	Options   map[string]string `xml:"-" json:"-"`                             // the options sent with the request, including the location as q, but not the key or format
 }
 
 // An Area Search Report
 type Search struct {
-	Area  []Area  `xml:"result"`    // the list of areas found
-	Error *string `xml:"error>msg"` // errors
+	XMLName   xml.Name          `xml:"data" json:"-"`                          // the root element of the response
+	Area      []Area            `xml:"result" json:"areas"`                    // the list of areas found
+	Error     *string           `xml:"error>msg" json:"error,omitempty"`       // errors
+	ErrorType *string           `xml:"error>type" json:"error_type,omitempty"` // type of error, if given
+	Raw       []byte            `xml:"-" json:"-"`                             // the response body, only if WWO.KeepRaw is set
+	FromCache bool              `xml:"-" json:"-"`                             // whether the response came from the WWO Cache
+	CachedAt  time.Time         `xml:"-" json:"-"`                             // when the response was cached, if it came from a Cache that records it
+	Options   map[string]string `xml:"-" json:"-"`                             // the options sent with the request, including the location as q, but not the key or format
 }
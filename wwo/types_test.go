@@ -0,0 +1,404 @@
+package wwo
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTideTime(t *testing.T) {
+	var w MarineWeather
+	err := xml.Unmarshal([]byte(`<weather><date>2024-06-01</date><tides>`+
+		`<tide_data><tideTime>4:12 AM</tideTime><tideHeight_mt>1.20</tideHeight_mt><tide_type>HIGH</tide_type></tide_data>`+
+		`<tide_data><tideTime>10:27 AM</tideTime><tideHeight_mt>0.30</tideHeight_mt><tide_type>LOW</tide_type></tide_data>`+
+		`</tides></weather>`), &w)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(w.Tide) != 2 {
+		t.Fatalf("%d tides, want 2", len(w.Tide))
+	}
+	if got, want := w.Tide[0].Time, Time12(4*time.Hour+12*time.Minute); got != want {
+		t.Errorf("Tide[0].Time = %v, want %v", got, want)
+	}
+	if got, want := w.Tide[1].Time, Time12(10*time.Hour+27*time.Minute); got != want {
+		t.Errorf("Tide[1].Time = %v, want %v", got, want)
+	}
+	if w.Tide[1].Height != 0.3 || w.Tide[1].Type != "LOW" {
+		t.Errorf("Tide[1] = %+v", w.Tide[1])
+	}
+}
+
+func TestTimeOn(t *testing.T) {
+	var d = Date(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	at, ok := Time12(6*time.Hour+30*time.Minute).On(d, nil)
+	if want := time.Date(2024, 6, 1, 6, 30, 0, 0, time.UTC); !ok || !at.Equal(want) {
+		t.Errorf("Time12.On with nil zone = %v, %v, want %v", at, ok, want)
+	}
+
+	at, ok = Time12(6*time.Hour+30*time.Minute).On(d, &Zone{Offset: 2})
+	if want := time.Date(2024, 6, 1, 4, 30, 0, 0, time.UTC); !ok || !at.Equal(want) {
+		t.Errorf("Time12.On with +2 zone = %v, %v, want %v", at, ok, want)
+	}
+
+	if at, ok := Time12(-1).On(d, nil); ok || !at.IsZero() {
+		t.Errorf("Time12.On for no event = %v, %v, want zero time and false", at, ok)
+	}
+
+	if at, want := TimeHMM(15*time.Hour).On(d, nil), time.Date(2024, 6, 1, 15, 0, 0, 0, time.UTC); !at.Equal(want) {
+		t.Errorf("TimeHMM.On with nil zone = %v, want %v", at, want)
+	}
+	if at, want := TimeHMM(15*time.Hour).On(d, &Zone{Offset: -5}), time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC); !at.Equal(want) {
+		t.Errorf("TimeHMM.On with -5 zone = %v, want %v", at, want)
+	}
+}
+
+func TestNoEvent(t *testing.T) {
+	var a Astronomy
+	if err := xml.Unmarshal([]byte(`<astronomy><moonrise>No moonrise</moonrise><moonset>10:15 AM</moonset></astronomy>`), &a); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Moonrise.Valid() {
+		t.Errorf("Moonrise %v is valid, want no event", time.Duration(a.Moonrise))
+	}
+	if got := a.Moonrise.String(); got != "No event" {
+		t.Errorf("Moonrise.String() = %q, want %q", got, "No event")
+	}
+	if !a.Moonset.Valid() || a.Moonset.String() != "10:15" {
+		t.Errorf("Moonset = %v, want 10:15", a.Moonset)
+	}
+}
+
+func TestMoonPhase(t *testing.T) {
+	var a Astronomy
+	if err := xml.Unmarshal([]byte(`<astronomy><moon_phase>Waxing Gibbous</moon_phase><moon_illumination>78</moon_illumination></astronomy>`), &a); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.MoonPhase != "Waxing Gibbous" || a.MoonIllumination != 78 {
+		t.Errorf("MoonPhase, MoonIllumination = %q, %d, want Waxing Gibbous, 78", a.MoonPhase, a.MoonIllumination)
+	}
+}
+
+func TestAirQuality(t *testing.T) {
+	var c Condition
+	if err := xml.Unmarshal([]byte(`<hourly><air_quality><co>230.3</co><pm2_5>8.4</pm2_5><pm10>12.1</pm10>`+
+		`<us-epa-index>1</us-epa-index><gb-defra-index>2</gb-defra-index></air_quality></hourly>`), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.AirQuality == nil {
+		t.Fatal("no air quality decoded")
+	}
+	if aq := *c.AirQuality; aq.PM25 != 8.4 || aq.PM10 != 12.1 || aq.USEPAIndex != 1 || aq.GBDEFRAIndex != 2 {
+		t.Errorf("AirQuality = %+v", aq)
+	}
+}
+
+func TestAlerts(t *testing.T) {
+	var l Local
+	if err := xml.Unmarshal([]byte(`<data><alerts>`+
+		`<alert><headline>Flood Warning issued</headline><msgtype>Alert</msgtype><severity>Moderate</severity>`+
+		`<event>Flood Warning</event><effective>2024-06-01T10:00:00+01:00</effective><expires>2024-06-02T10:00:00+01:00</expires></alert>`+
+		`<alert><headline>Heat advisory</headline><effective>2024-06-01T12:00:00Z</effective><expires></expires></alert>`+
+		`</alerts></data>`), &l); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(l.Alerts) != 2 {
+		t.Fatalf("%d alerts, want 2", len(l.Alerts))
+	}
+
+	a := l.Alerts[0]
+	if a.Headline != "Flood Warning issued" || a.Severity != "Moderate" || a.Event != "Flood Warning" {
+		t.Errorf("Alerts[0] = %+v", a)
+	}
+	if want := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC); !time.Time(a.Effective).Equal(want) {
+		t.Errorf("Effective = %v, want %v", a.Effective, want)
+	}
+	if want := time.Date(2024, 6, 2, 9, 0, 0, 0, time.UTC); !time.Time(a.Expires).Equal(want) {
+		t.Errorf("Expires = %v, want %v", a.Expires, want)
+	}
+	if !time.Time(l.Alerts[1].Expires).IsZero() {
+		t.Errorf("Expires of an alert without one = %v, want zero", l.Alerts[1].Expires)
+	}
+}
+
+func TestIsDayTime(t *testing.T) {
+	for body, want := range map[string]YesNo{
+		`<hourly><isdaytime>no</isdaytime></hourly>`:  false,
+		`<hourly><isdaytime>yes</isdaytime></hourly>`: true,
+		`<hourly><isdaytime>Yes</isdaytime></hourly>`: true,
+	} {
+		var c Condition
+		if err := xml.Unmarshal([]byte(body), &c); err != nil {
+			t.Fatal(err)
+		}
+		if c.IsDayTime != want {
+			t.Errorf("IsDayTime of %s = %v, want %v", body, c.IsDayTime, want)
+		}
+	}
+}
+
+func TestUTC(t *testing.T) {
+	var c Condition
+	if err := xml.Unmarshal([]byte(`<hourly><time>900</time><UTCdate>2024-06-01</UTCdate><UTCtime>2300</UTCtime></hourly>`), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.UTCDate.String() != "2024-06-01" || c.UTCTime != TimeHMM(23*time.Hour) {
+		t.Errorf("UTCDate, UTCTime = %v, %v", c.UTCDate, c.UTCTime)
+	}
+	if got, want := c.UTC(), time.Date(2024, 6, 1, 23, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("UTC() = %v, want %v", got, want)
+	}
+
+	if got := (&Condition{}).UTC(); !got.IsZero() {
+		t.Errorf("UTC() without UTC fields = %v, want zero", got)
+	}
+}
+
+func TestJSONNames(t *testing.T) {
+	var l = Local{
+		Area:    Area{Name: "London", Country: "United Kingdom"},
+		Request: Request{Query: "London, United Kingdom", Type: "City"},
+	}
+	l.Current.Temp = 12
+	l.Current.WindSpeed = 15
+	l.Weather = []ForecastWeather{{}}
+	l.Weather[0].MaxTemp = 20
+	l.Weather[0].Condition = []ForecastCondition{{}}
+	l.Weather[0].Condition[0].ChanceRain = 70
+
+	text, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v struct {
+		Area struct {
+			Name    string `json:"name"`
+			Country string `json:"country"`
+		} `json:"area"`
+		Request struct {
+			Query string `json:"query"`
+		} `json:"request"`
+		Current map[string]interface{} `json:"current"`
+		Weather []struct {
+			MaxTemp int                      `json:"max_temp_c"`
+			Hourly  []map[string]interface{} `json:"hourly"`
+		} `json:"weather"`
+	}
+	if err := json.Unmarshal(text, &v); err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Area.Name != "London" || v.Area.Country != "United Kingdom" || v.Request.Query != "London, United Kingdom" {
+		t.Errorf("area and request not encoded as name, country, and query: %s", text)
+	}
+	if v.Current["temp_c"] != 12.0 || v.Current["wind_speed_kmph"] != 15.0 {
+		t.Errorf("current conditions not encoded as temp_c and wind_speed_kmph: %s", text)
+	}
+	if len(v.Weather) != 1 || v.Weather[0].MaxTemp != 20 || len(v.Weather[0].Hourly) != 1 || v.Weather[0].Hourly[0]["chance_rain"] != 70.0 {
+		t.Errorf("weather not encoded as max_temp_c and hourly chance_rain: %s", text)
+	}
+
+	var top map[string]interface{}
+	if err := json.Unmarshal(text, &top); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Raw", "FromCache", "CachedAt", "Options", "error"} {
+		if _, ok := top[name]; ok {
+			t.Errorf("%s encoded in %s", name, text)
+		}
+	}
+	if _, ok := v.Current["Elements"]; ok {
+		t.Errorf("Elements encoded in %s", text)
+	}
+}
+
+func TestTimeTypesJSON(t *testing.T) {
+	type times struct {
+		Date     Date
+		Sunrise  Time12
+		Moonrise Time12
+		Time     TimeHMM
+	}
+
+	var in = times{
+		Date:     Date(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)),
+		Sunrise:  Time12(4*time.Hour + 45*time.Minute),
+		Moonrise: Time12(-1),
+		Time:     TimeHMM(15 * time.Hour),
+	}
+
+	text, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"Date":"2024-06-01","Sunrise":"04:45","Moonrise":null,"Time":"15:00"}`; string(text) != want {
+		t.Errorf("encoded %s, want %s", text, want)
+	}
+
+	var out times
+	if err := json.Unmarshal(text, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !time.Time(out.Date).Equal(time.Time(in.Date)) || out.Sunrise != in.Sunrise || out.Moonrise != in.Moonrise || out.Time != in.Time {
+		t.Errorf("decoded %+v, want %+v", out, in)
+	}
+}
+
+// A local forecast as the API gives it, without extras or the nearest area.
+const localXML = `<data><request><type>City</type><query>London, United Kingdom</query></request>` +
+	`<current_condition><observation_time>12:15 PM</observation_time><temp_C>0</temp_C><temp_F>32</temp_F>` +
+	`<weatherCode>116</weatherCode><weatherDesc>Partly cloudy</weatherDesc><windspeedKmph>15</windspeedKmph>` +
+	`<winddir16Point>NW</winddir16Point><humidity>80</humidity></current_condition>` +
+	`<weather><date>2024-06-01</date>` +
+	`<astronomy><sunrise>04:45 AM</sunrise><sunset>09:10 PM</sunset><moonrise>No moonrise</moonrise><moonset>04:39 PM</moonset></astronomy>` +
+	`<maxtempC>20</maxtempC><maxtempF>68</maxtempF><mintempC>10</mintempC><mintempF>50</mintempF>` +
+	`<hourly><time>0</time><tempC>10</tempC><tempF>50</tempF><weatherCode>113</weatherCode><chanceofrain>0</chanceofrain></hourly>` +
+	`<hourly><time>1200</time><tempC>19</tempC><tempF>66</tempF><weatherCode>176</weatherCode><chanceofrain>70</chanceofrain></hourly>` +
+	`</weather></data>`
+
+func TestMarshalXML(t *testing.T) {
+	var l Local
+	if err := xml.Unmarshal([]byte(localXML), &l); err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := xml.Marshal(&l)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(string(text), "<data>") {
+		t.Errorf("root element is not data: %s", text)
+	}
+	for _, element := range []string{"<DewPointC>", "<HeatIndexC>", "<isdaytime>", "<UTCdate>", "<nearest_area>", "<comment>", "<tempC>0</tempC>", "<time>0</time><temp_C>"} {
+		if strings.Contains(string(text), element) {
+			t.Errorf("%s not in the response encoded in %s", element, text)
+		}
+	}
+
+	var again Local
+	if err := xml.Unmarshal(text, &again); err != nil {
+		t.Fatalf("decoding %s: %v", text, err)
+	}
+
+	if c := again.Current; c.Time != l.Current.Time || c.Temp != 0 || c.TempF != 32 || c.WeatherDesc != "Partly cloudy" || c.WindDirCompass != "NW" {
+		t.Errorf("Current = %+v, want %+v", c, l.Current)
+	}
+	if len(again.Weather) != 1 || len(again.Weather[0].Condition) != 2 {
+		t.Fatalf("weather not encoded: %s", text)
+	}
+
+	w := again.Weather[0]
+	if w.Date != l.Weather[0].Date || w.TempRange != l.Weather[0].TempRange || w.Astronomy.Sunset != l.Weather[0].Astronomy.Sunset || w.Astronomy.Moonrise.Valid() {
+		t.Errorf("Weather = %+v, want %+v", w.Weather, l.Weather[0].Weather)
+	}
+	if c := w.Condition[1]; c.Time != TimeHMM(12*time.Hour) || c.Temp != 19 || c.ChanceRain != 70 {
+		t.Errorf("Condition[1] = %+v", c)
+	}
+
+	for _, field := range []string{"Temp", "TempF", "Humidity", "DewPoint", "FeelsLike", "WindDir", "IsDayTime"} {
+		if again.Current.Has(field) != l.Current.Has(field) {
+			t.Errorf("Current.Has(%s) = %v after encoding, %v before", field, again.Current.Has(field), l.Current.Has(field))
+		}
+		if w.Condition[0].Has(field) != l.Weather[0].Condition[0].Has(field) {
+			t.Errorf("Condition[0].Has(%s) = %v after encoding, %v before", field, w.Condition[0].Has(field), l.Weather[0].Condition[0].Has(field))
+		}
+	}
+}
+
+func TestComment(t *testing.T) {
+	var l Local
+	err := xml.Unmarshal([]byte(`<data><weather><date>2024-06-01</date><comment>Showers clearing by the afternoon.</comment></weather></data>`), &l)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(l.Weather) != 1 || l.Weather[0].Comment != "Showers clearing by the afternoon." {
+		t.Errorf("Weather = %+v, want the comment", l.Weather)
+	}
+	if got := (LocalOptions{ShowComments: true}).Map()["show_comments"]; got != "yes" {
+		t.Errorf("show_comments=%q, want yes", got)
+	}
+}
+
+func TestDaylight(t *testing.T) {
+	for _, tt := range []struct {
+		sunrise, sunset string
+		want            time.Duration
+		ok              bool
+	}{
+		{"06:12 AM", "06:40 PM", 12*time.Hour + 28*time.Minute, true},
+		{"No sunrise", "06:40 PM", 0, false},
+		{"06:12 AM", "No sunset", 0, false},
+	} {
+		var a Astronomy
+		in := "<astronomy><sunrise>" + tt.sunrise + "</sunrise><sunset>" + tt.sunset + "</sunset></astronomy>"
+		if err := xml.Unmarshal([]byte(in), &a); err != nil {
+			t.Fatal(err)
+		}
+
+		if d, ok := a.Daylight(); d != tt.want || ok != tt.ok {
+			t.Errorf("%s to %s: Daylight() = %v, %v, want %v, %v", tt.sunrise, tt.sunset, d, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPolarDay(t *testing.T) {
+	var june, december = Date(time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)), Date(time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC))
+	const arctic, antarctic = 78.2, -77.8
+
+	for _, tt := range []struct {
+		sunrise, sunset string
+		d               Date
+		lat             float64
+		day, night      bool
+	}{
+		{"No sunrise", "No sunset", june, arctic, true, false},
+		{"No sunrise", "No sunset", december, arctic, false, true},
+		{"No sunrise", "No sunset", june, antarctic, false, true},
+		{"No sunrise", "No sunset", december, antarctic, true, false},
+		{"01:05 AM", "No sunset", june, arctic, true, false},
+		{"No sunrise", "01:20 PM", december, arctic, false, true},
+		{"04:45 AM", "09:10 PM", june, 51.5, false, false},
+	} {
+		var a Astronomy
+		in := "<astronomy><sunrise>" + tt.sunrise + "</sunrise><sunset>" + tt.sunset + "</sunset></astronomy>"
+		if err := xml.Unmarshal([]byte(in), &a); err != nil {
+			t.Fatal(err)
+		}
+
+		if day, night := a.IsPolarDay(tt.d, tt.lat), a.IsPolarNight(tt.d, tt.lat); day != tt.day || night != tt.night {
+			t.Errorf("%s to %s on %v at %v°: IsPolarDay() %v, IsPolarNight() %v, want %v, %v",
+				tt.sunrise, tt.sunset, time.Time(tt.d).Format("2006-01-02"), tt.lat, day, night, tt.day, tt.night)
+		}
+	}
+}
+
+func TestZoneLocation(t *testing.T) {
+	for _, tt := range []struct {
+		z      *Zone
+		name   string
+		offset int
+	}{
+		{&Zone{Offset: 5.75}, "UTC+05:45", 20700},
+		{&Zone{Offset: 5.5}, "UTC+05:30", 19800},
+		{&Zone{Offset: -3.5}, "UTC-03:30", -12600},
+		{&Zone{Offset: 0}, "UTC", 0},
+		{nil, "UTC", 0},
+	} {
+		name, offset := time.Date(2024, 6, 1, 12, 0, 0, 0, tt.z.Location()).Zone()
+		if name != tt.name || offset != tt.offset {
+			t.Errorf("Location() of %+v is %s, %ds, want %s, %ds", tt.z, name, offset, tt.name, tt.offset)
+		}
+	}
+}
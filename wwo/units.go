@@ -0,0 +1,108 @@
+package wwo
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Systems of units in which conditions may be presented.
+type Unit int
+
+const (
+	Metric   Unit = iota // °C, km/h, mbar, km, mm
+	Imperial             // °F, mph, in, mi, in
+)
+
+// Temperature in the system of units u, with its unit symbol.
+func (c *Condition) TempAs(u Unit) (int, string) {
+	if u == Imperial {
+		return c.TempF, "°F"
+	}
+	return c.Temp, "°C"
+}
+
+// Temperature in the system of units u, with its unit symbol.
+func (c *CurrentCondition) TempAs(u Unit) (int, string) {
+	if u == Imperial {
+		return c.TempF, "°F"
+	}
+	return c.Temp, "°C"
+}
+
+// Temperature in the system of units u, with its unit symbol.
+func (c *LevelCond) TempAs(u Unit) (int, string) {
+	if u == Imperial {
+		return c.TempF, "°F"
+	}
+	return c.Temp, "°C"
+}
+
+// Wind speed in the system of units u, with its unit symbol.
+func (c *Condition) WindSpeedAs(u Unit) (uint, string) {
+	if u == Imperial {
+		return c.WindSpeedMiles, "mph"
+	}
+	return c.WindSpeed, "km/h"
+}
+
+// Atmospheric pressure in the system of units u, with its unit symbol.
+func (c *Condition) PressureAs(u Unit) (uint, string) {
+	if u == Imperial {
+		return c.PressureInches, "in"
+	}
+	return c.Pressure, "mbar"
+}
+
+// Atmospheric pressure in the named unit, "mbar", "hPa", or "inHg".
+// Inches of mercury are converted from millibars, as PressureInches is only given to the nearest inch.
+func (c *Condition) PressureIn(unit string) (float64, error) {
+	switch unit {
+	case "mbar", "hPa":
+		return float64(c.Pressure), nil
+	case "inHg":
+		return float64(c.Pressure) * 0.0295299830714, nil
+	}
+	return 0, errors.New("wwo: unknown pressure unit " + strconv.Quote(unit))
+}
+
+// Visibility in the system of units u, with its unit symbol.
+func (c *Condition) VisibilityAs(u Unit) (uint, string) {
+	if u == Imperial {
+		return c.VisibilityMiles, "mi"
+	}
+	return c.Visibility, "km"
+}
+
+// Visibility in the named unit, "km", "m", or "mi".
+func (c *Condition) VisibilityIn(unit string) (float64, error) {
+	switch unit {
+	case "km":
+		return float64(c.Visibility), nil
+	case "m":
+		return float64(c.Visibility) * 1000, nil
+	case "mi":
+		return float64(c.VisibilityMiles), nil
+	}
+	return 0, errors.New("wwo: unknown visibility unit " + strconv.Quote(unit))
+}
+
+// Precipitation in the system of units u, with its unit symbol.
+func (c *Condition) PrecipAs(u Unit) (float64, string) {
+	if u == Imperial {
+		return c.PrecipInches, "in"
+	}
+	return c.Precip, "mm"
+}
+
+// Distance between the query point and the area in kilometres, from DistanceMI.
+func (a Area) DistanceKM() float64 {
+	return a.DistanceMI * 1.609344
+}
+
+// Distance between the query point and the area in the system of units u, with its unit symbol.
+func (a Area) DistanceAs(u Unit) (float64, string) {
+	if u == Imperial {
+		return a.DistanceMI, "mi"
+	}
+	return a.DistanceKM(), "km"
+}
@@ -0,0 +1,80 @@
+package wwo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUnitAccessors(t *testing.T) {
+	var c = Condition{
+		Temp: 12, TempF: 54,
+		WindSpeed: 15, WindSpeedMiles: 9,
+		Pressure: 1013, PressureInches: 30,
+		Visibility: 10, VisibilityMiles: 6,
+		Precip: 2.5, PrecipInches: 0.1,
+	}
+
+	for _, tt := range []struct {
+		unit                  Unit
+		temp, wind, pres, vis uint
+		precip                float64
+		symbols               [5]string
+	}{
+		{Metric, 12, 15, 1013, 10, 2.5, [5]string{"°C", "km/h", "mbar", "km", "mm"}},
+		{Imperial, 54, 9, 30, 6, 0.1, [5]string{"°F", "mph", "in", "mi", "in"}},
+	} {
+		temp, tu := c.TempAs(tt.unit)
+		wind, wu := c.WindSpeedAs(tt.unit)
+		pres, pu := c.PressureAs(tt.unit)
+		vis, vu := c.VisibilityAs(tt.unit)
+		precip, ru := c.PrecipAs(tt.unit)
+
+		if uint(temp) != tt.temp || wind != tt.wind || pres != tt.pres || vis != tt.vis || precip != tt.precip {
+			t.Errorf("unit %d: read %d, %d, %d, %d, %g, want %d, %d, %d, %d, %g",
+				tt.unit, temp, wind, pres, vis, precip, tt.temp, tt.wind, tt.pres, tt.vis, tt.precip)
+		}
+		if got := [5]string{tu, wu, pu, vu, ru}; got != tt.symbols {
+			t.Errorf("unit %d: symbols %v, want %v", tt.unit, got, tt.symbols)
+		}
+	}
+
+	if got, want := c.StringAs(Imperial), "54°F, wind 9 mph"; got != want {
+		t.Errorf("StringAs(Imperial) = %q, want %q", got, want)
+	}
+}
+
+func TestDistanceKM(t *testing.T) {
+	var a = Area{DistanceMI: 10}
+
+	if got := a.DistanceKM(); math.Abs(got-16.09) > 0.005 {
+		t.Errorf("DistanceKM() = %v, want 16.09", got)
+	}
+	if d, unit := a.DistanceAs(Imperial); d != 10 || unit != "mi" {
+		t.Errorf("DistanceAs(Imperial) = %v, %q, want 10, mi", d, unit)
+	}
+	if d, unit := a.DistanceAs(Metric); d != a.DistanceKM() || unit != "km" {
+		t.Errorf("DistanceAs(Metric) = %v, %q, want %v, km", d, unit, a.DistanceKM())
+	}
+}
+
+func TestPressureIn(t *testing.T) {
+	var c = Condition{Pressure: 1013, PressureInches: 30}
+
+	for _, tt := range []struct {
+		unit string
+		want float64
+	}{
+		{"mbar", 1013},
+		{"hPa", 1013},
+		{"inHg", 29.91},
+	} {
+		got, err := c.PressureIn(tt.unit)
+		if err != nil || math.Abs(got-tt.want) > 0.005 {
+			t.Errorf("PressureIn(%q) = %v, %v, want %v", tt.unit, got, err, tt.want)
+		}
+	}
+
+	if _, err := c.PressureIn("atm"); err == nil {
+		t.Error("PressureIn(\"atm\") gave no error")
+	}
+}
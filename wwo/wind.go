@@ -0,0 +1,27 @@
+package wwo
+
+// Wind speed in each of the units given, with its direction and gusts.
+type Wind struct {
+	Kmph       uint   // km/hr  Wind speed
+	Knots      uint   // knots  Wind speed
+	Ms         uint   // m/s    Wind speed
+	Mph        uint   // mi/hr  Wind speed
+	DirDeg     uint   // °EoN   Wind direction
+	DirCompass string //        Wind direction 16-point compass
+	GustKmph   uint   // km/hr  Wind gust, only when requested with extra=windGust
+	GustMph    uint   // mi/hr  Wind gust, only when requested with extra=windGust
+}
+
+// The wind of the conditions, from its separate fields.
+func (c *Condition) Wind() Wind {
+	return Wind{
+		Kmph:       c.WindSpeed,
+		Knots:      c.WindSpeedKnots,
+		Ms:         c.WindSpeedMeterSec,
+		Mph:        c.WindSpeedMiles,
+		DirDeg:     c.WindDir,
+		DirCompass: c.WindDirCompass,
+		GustKmph:   c.WindGust,
+		GustMph:    c.WindGustMiles,
+	}
+}
@@ -0,0 +1,23 @@
+package wwo
+
+import (
+	"testing"
+)
+
+func TestWind(t *testing.T) {
+	var c = Condition{
+		WindSpeed:         20,
+		WindSpeedKnots:    11,
+		WindSpeedMeterSec: 6,
+		WindSpeedMiles:    12,
+		WindDir:           225,
+		WindDirCompass:    "SW",
+		WindGust:          31,
+		WindGustMiles:     19,
+	}
+
+	want := Wind{Kmph: 20, Knots: 11, Ms: 6, Mph: 12, DirDeg: 225, DirCompass: "SW", GustKmph: 31, GustMph: 19}
+	if got := c.Wind(); got != want {
+		t.Errorf("Wind() = %+v, want %+v", got, want)
+	}
+}